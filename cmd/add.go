@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sandermoonemans/local-brain/pkg/api"
 	"github.com/sandermoonemans/local-brain/pkg/config"
 	"github.com/sandermoonemans/local-brain/pkg/external"
 	"github.com/sandermoonemans/local-brain/pkg/fileutil"
@@ -22,19 +23,60 @@ var addCmd = &cobra.Command{
 Quick capture (with text):
   Appends a task to the dump: - [ ] your text #captured:YYYY-MM-DD
 
+  Inline metadata is parsed at capture time:
+    #due:<date> or due:<date>  Due date (tomorrow, +3d, next-friday, ...)
+    !1, !2, !3                 Priority shorthand for #p:1, #p:2, #p:3
+    +project                   Append directly to the project's todo.md
+
 Editor mode (no text):
   Opens editor for writing notes. On close, the content is appended
   to dump as an indented note block:
     [Note] Title #captured:YYYY-MM-DD
-        Your note content here...`,
+        Your note content here...
+  --template <name> pre-fills the editor with <brain>/templates/notes/<name>.md
+
+Typed capture:
+  --link <url> [title]  Appends: [Link] url title #captured:YYYY-MM-DD
+  --idea <text>         Appends: [Idea] text #captured:YYYY-MM-DD
+  --question <text>     Appends: [Question] text #captured:YYYY-MM-DD
+
+Piped capture (for scripts):
+  brain add -                Read one task per line from stdin
+  brain add --note "Title"   Read note content from stdin
+  --from-file <path>         Read from a file instead of stdin`,
 	Example: `  brain add "Fix the authentication bug"
   brain add "Email Sarah about proposal"
-  brain add                              # Opens editor for meeting notes`,
+  brain add "call bank #due:tomorrow !1"   # Due date and priority
+  brain add "+backend rotate API keys"     # Straight into a project
+  brain add                              # Opens editor for meeting notes
+  brain add --template meeting           # Editor pre-filled from a template
+  brain add --link https://go.dev/blog "Go blog"
+  brain add --idea "Offline mode for the app"
+  grep -rn TODO src/ | brain add -         # One task per line
+  brain add --note "Standup" < meeting.txt # Note from stdin
+  brain add --from-file tasks.txt          # One task per line from file`,
 	RunE: runAdd,
 }
 
+var (
+	addNoteFlag     string
+	addFromFileFlag string
+	addLinkFlag     string
+	addIdeaFlag     bool
+	addQuestionFlag bool
+	addTemplateFlag string
+)
+
 func init() {
 	rootCmd.AddCommand(addCmd)
+
+	addCmd.Flags().StringVar(&addNoteFlag, "note", "", "Capture a note with this title, reading content from stdin")
+	addCmd.Flags().StringVar(&addFromFileFlag, "from-file", "", "Read input from a file instead of stdin")
+	addCmd.Flags().StringVar(&addLinkFlag, "link", "", "Capture a link (remaining text is the title)")
+	addCmd.Flags().BoolVar(&addIdeaFlag, "idea", false, "Capture text as an idea")
+	addCmd.Flags().BoolVar(&addQuestionFlag, "question", false, "Capture text as a question")
+	addCmd.Flags().StringVar(&addTemplateFlag, "template", "", "Pre-fill editor mode with a note template")
+	addCmd.MarkFlagsMutuallyExclusive("link", "idea", "question", "note", "template")
 }
 
 func runAdd(cmd *cobra.Command, args []string) error {
@@ -55,26 +97,59 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("dump not found at %s. Run 'brain new' first", dumpPath)
 	}
 
+	if err := addCapture(brainPath, dumpPath, args); err != nil {
+		return err
+	}
+
+	warnStaleDump(cfg, dumpPath)
+	return nil
+}
+
+// addCapture dispatches to the capture mode selected by flags and arguments
+func addCapture(brainPath, dumpPath string, args []string) error {
 	timestamp := time.Now().Format("2006-01-02")
 
-	// Quick capture mode: brain add "text"
-	if len(args) > 0 {
-		text := strings.Join(args, " ")
+	if addTemplateFlag != "" && (len(args) > 0 || addFromFileFlag != "") {
+		return fmt.Errorf("--template only works in editor mode (brain add --template <name>)")
+	}
 
-		// Acquire lock and append
-		err := fileutil.WithLock(dumpPath, func() error {
-			f, err := os.OpenFile(dumpPath, os.O_APPEND|os.O_WRONLY, 0644)
-			if err != nil {
-				return err
-			}
-			defer f.Close()
+	// Piped capture mode: brain add -, brain add --note "Title", brain add --from-file <path>
+	readStdin := len(args) == 1 && args[0] == "-"
+	if readStdin || addNoteFlag != "" || addFromFileFlag != "" {
+		if len(args) > 0 && !readStdin {
+			return fmt.Errorf("cannot combine text arguments with --note or --from-file")
+		}
 
-			line := fmt.Sprintf("- [ ] %s #captured:%s\n", text, timestamp)
-			_, err = f.WriteString(line)
+		lines, err := readInputLines(addFromFileFlag)
+		if err != nil {
 			return err
-		})
+		}
+
+		if addNoteFlag != "" {
+			return addNoteFromLines(dumpPath, addNoteFlag, lines, timestamp)
+		}
+		return addTasksFromLines(dumpPath, filepath.Join(brainPath, "01_active"), lines, timestamp)
+	}
+
+	// Typed capture mode: brain add --link <url> [title], --idea, --question
+	if addLinkFlag != "" || addIdeaFlag || addQuestionFlag {
+		return addLineItem(dumpPath, strings.Join(args, " "), timestamp)
+	}
 
+	// Quick capture mode: brain add "text"
+	if len(args) > 0 {
+		capture, err := api.ParseCaptureText(strings.Join(args, " "))
 		if err != nil {
+			return err
+		}
+
+		// Project routing: brain add "+project text" bypasses the dump
+		if capture.Project != "" {
+			return addToProject(filepath.Join(brainPath, "01_active"), capture, timestamp)
+		}
+
+		line := fmt.Sprintf("- [ ] %s #captured:%s", capture.Text, timestamp)
+		if err := appendDumpLine(dumpPath, line); err != nil {
 			return fmt.Errorf("failed to append to dump: %w", err)
 		}
 
@@ -83,10 +158,107 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	// Editor mode: brain add (no args)
-	return addNoteMode(dumpPath, timestamp)
+	tmpl := ""
+	if addTemplateFlag != "" {
+		var err error
+		if tmpl, err = api.LoadNoteTemplate(brainPath, addTemplateFlag); err != nil {
+			return err
+		}
+	}
+	return addNoteMode(dumpPath, timestamp, tmpl)
+}
+
+// addLineItem captures a single-line [Link], [Idea] or [Question] item
+func addLineItem(dumpPath, text, timestamp string) error {
+	var prefix, body string
+	switch {
+	case addLinkFlag != "":
+		prefix = "Link"
+		body = strings.TrimSpace(addLinkFlag + " " + text)
+	case addIdeaFlag:
+		prefix = "Idea"
+		body = text
+	case addQuestionFlag:
+		prefix = "Question"
+		body = text
+	}
+
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("nothing to capture")
+	}
+
+	line := fmt.Sprintf("[%s] %s #captured:%s", prefix, body, timestamp)
+	if err := appendDumpLine(dumpPath, line); err != nil {
+		return fmt.Errorf("failed to append to dump: %w", err)
+	}
+
+	fmt.Printf("OK: Added %s to dump\n", strings.ToLower(prefix))
+	return nil
+}
+
+// appendDumpLine appends a single line to the dump while holding the dump lock
+func appendDumpLine(dumpPath, line string) error {
+	return fileutil.WithLock(dumpPath, func() error {
+		f, err := os.OpenFile(dumpPath, os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = f.WriteString(line + "\n")
+		return err
+	})
+}
+
+func addToProject(activeDir string, capture api.CaptureInput, timestamp string) error {
+	projectName, projectDir, err := resolveCaptureProject(activeDir, capture.Project)
+	if err != nil {
+		return err
+	}
+
+	return appendCapture(projectName, projectDir, capture, timestamp)
+}
+
+// resolveCaptureProject matches a +project prefix to a project and its directory
+func resolveCaptureProject(activeDir, prefix string) (string, string, error) {
+	projects, err := listProjects(activeDir)
+	if err != nil {
+		return "", "", err
+	}
+
+	projectName, err := api.MatchProjectName(projects, prefix)
+	if err != nil {
+		return "", "", err
+	}
+
+	projectDir, err := api.FindProjectDir(activeDir, projectName)
+	if err != nil {
+		return "", "", err
+	}
+
+	return projectName, projectDir, nil
 }
 
-func addNoteMode(dumpPath, timestamp string) error {
+// appendCapture appends a captured task to a resolved project
+func appendCapture(projectName, projectDir string, capture api.CaptureInput, timestamp string) error {
+	content := fmt.Sprintf("%s #captured:%s", capture.Text, timestamp)
+	if err := appendTaskToProject(projectDir, content); err != nil {
+		return fmt.Errorf("failed to append to %s: %w", projectName, err)
+	}
+
+	fmt.Printf("OK: Added task to %s\n", projectName)
+	return nil
+}
+
+// noteEditorHeader is shown at the top of the editor in note mode and removed afterwards
+var noteEditorHeader = []string{
+	"# Write your note below. This line will be removed.",
+	"# Save and close the editor when done.",
+}
+
+// addNoteMode prompts for a title and captures a note written in the editor
+// A non-empty tmpl pre-fills the editor with the rendered template body
+func addNoteMode(dumpPath, timestamp, tmpl string) error {
 	// Prompt for title
 	fmt.Print("Note title: ")
 	reader := bufio.NewReader(os.Stdin)
@@ -102,10 +274,14 @@ func addNoteMode(dumpPath, timestamp string) error {
 	}
 
 	// Create temp file with helpful header
-	initialContent := `# Write your note below. This line will be removed.
-# Save and close the editor when done.
-
-`
+	initialContent := strings.Join(noteEditorHeader, "\n") + "\n\n"
+	if tmpl != "" {
+		project := ""
+		if cfg, err := config.Load(); err == nil {
+			project = cfg.GetFocusedProject()
+		}
+		initialContent += api.TemplateBody(tmpl, api.NoteTemplateVars(title, project, time.Now()))
+	}
 
 	// Open editor
 	editor, err := external.DetectEditor()
@@ -118,30 +294,49 @@ func addNoteMode(dumpPath, timestamp string) error {
 		return fmt.Errorf("editor failed: %w", err)
 	}
 
-	// Process content: strip comment lines and empty lines
-	lines := strings.Split(content, "\n")
+	// Process content: strip the editor header, keep markdown headings
 	var cleanLines []string
-	for _, line := range lines {
-		if strings.HasPrefix(line, "#") {
+	for _, line := range strings.Split(content, "\n") {
+		if isNoteEditorHeader(line) {
 			continue
 		}
-		if strings.TrimSpace(line) != "" || len(cleanLines) > 0 {
-			cleanLines = append(cleanLines, line)
+		cleanLines = append(cleanLines, line)
+	}
+
+	return addNoteFromLines(dumpPath, title, cleanLines, timestamp)
+}
+
+func isNoteEditorHeader(line string) bool {
+	for _, header := range noteEditorHeader {
+		if line == header {
+			return true
 		}
 	}
+	return false
+}
 
-	// Remove trailing empty lines
-	for len(cleanLines) > 0 && strings.TrimSpace(cleanLines[len(cleanLines)-1]) == "" {
-		cleanLines = cleanLines[:len(cleanLines)-1]
+// addNoteFromLines appends a [Note] block with the given content lines to the dump
+func addNoteFromLines(dumpPath, title string, lines []string, timestamp string) error {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return fmt.Errorf("note title cannot be empty")
 	}
 
-	if len(cleanLines) == 0 {
+	// Remove leading and trailing empty lines
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
 		fmt.Println("Aborted (empty note)")
 		return nil
 	}
 
 	// Append note to dump
-	err = fileutil.WithLock(dumpPath, func() error {
+	err := fileutil.WithLock(dumpPath, func() error {
 		f, err := os.OpenFile(dumpPath, os.O_APPEND|os.O_WRONLY, 0644)
 		if err != nil {
 			return err
@@ -155,7 +350,7 @@ func addNoteMode(dumpPath, timestamp string) error {
 		}
 
 		// Write indented content
-		for _, line := range cleanLines {
+		for _, line := range lines {
 			indentedLine := fmt.Sprintf("    %s\n", line)
 			if _, err := f.WriteString(indentedLine); err != nil {
 				return err
@@ -172,3 +367,102 @@ func addNoteMode(dumpPath, timestamp string) error {
 	fmt.Println("OK: Added note to dump")
 	return nil
 }
+
+// addTasksFromLines captures one task per non-empty line
+// All lines are parsed and their +project names resolved before anything is
+// written, so a bad line or unknown project aborts the whole batch
+func addTasksFromLines(dumpPath, activeDir string, lines []string, timestamp string) error {
+	type projectTask struct {
+		capture api.CaptureInput
+		name    string
+		dir     string
+	}
+
+	var dumpTasks []string
+	var projectTasks []projectTask
+
+	for _, line := range lines {
+		// Accept lines that are already formatted as tasks
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "- [ ] "))
+		if line == "" {
+			continue
+		}
+
+		capture, err := api.ParseCaptureText(line)
+		if err != nil {
+			return fmt.Errorf("invalid line '%s': %w", line, err)
+		}
+
+		if capture.Project != "" {
+			name, dir, err := resolveCaptureProject(activeDir, capture.Project)
+			if err != nil {
+				return fmt.Errorf("invalid line '%s': %w", line, err)
+			}
+			projectTasks = append(projectTasks, projectTask{capture: capture, name: name, dir: dir})
+		} else {
+			dumpTasks = append(dumpTasks, capture.Text)
+		}
+	}
+
+	if len(dumpTasks) == 0 && len(projectTasks) == 0 {
+		fmt.Println("Aborted (no input)")
+		return nil
+	}
+
+	if len(dumpTasks) > 0 {
+		err := fileutil.WithLock(dumpPath, func() error {
+			f, err := os.OpenFile(dumpPath, os.O_APPEND|os.O_WRONLY, 0644)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			for _, text := range dumpTasks {
+				if _, err := fmt.Fprintf(f, "- [ ] %s #captured:%s\n", text, timestamp); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed to append to dump: %w", err)
+		}
+
+		fmt.Printf("OK: Added %d task(s) to dump\n", len(dumpTasks))
+	}
+
+	for _, task := range projectTasks {
+		if err := appendCapture(task.name, task.dir, task.capture, timestamp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readInputLines reads all lines from a file, or from stdin when path is empty
+func readInputLines(path string) ([]string, error) {
+	input := os.Stdin
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open input file: %w", err)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/external"
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	attachmentsJSONFlag   bool
+	attachmentsDryRunFlag bool
+)
+
+var noteAttachCmd = &cobra.Command{
+	Use:   "attach <file> [note]",
+	Short: "Attach a file to a note",
+	Long: `Copy a file into the focused project's attachments/ directory and link it from a note.
+
+The note is notes.md or a file in notes/ (with or without .md). Without a
+note, pick one with fzf. Images are linked as ![name](path) so they render
+inline; other files as [name](path).
+
+Attachments are de-duplicated by content: attaching the same file twice
+reuses the existing copy.`,
+	Example: `  brain note attach ~/Desktop/screenshot.png 2024-01-15-design-review
+  brain note attach /tmp/crash.log notes.md
+  brain note attach report.pdf                # Pick the note with fzf`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runNoteAttach,
+}
+
+var attachmentsCmd = &cobra.Command{
+	Use:   "attachments",
+	Short: "Manage project attachments",
+	Long: `Manage files in a project's attachments/ directory.
+
+Subcommands:
+  ls     List attachments and the files that link to them
+  prune  Delete attachments no note or task links to
+
+Attach files with: brain note attach <file> [note]`,
+}
+
+var attachmentsLsCmd = &cobra.Command{
+	Use:   "ls [project]",
+	Short: "List attachments",
+	Long:  "List attachments of a project (default: focused project) with the notes and task files that reference them",
+	Example: `  brain attachments ls
+  brain attachments ls backend-api --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAttachmentsLs,
+}
+
+var attachmentsPruneCmd = &cobra.Command{
+	Use:   "prune [project]",
+	Short: "Delete unreferenced attachments",
+	Long: `Delete attachments that are not linked from todo.md, notes.md or notes/*.md.
+
+Inline links ([x](attachments/f.png)), reference definitions
+([x]: attachments/f.png) and HTML src=/href= attributes count as links.
+
+Use --dry-run to see what would be deleted.`,
+	Example: `  brain attachments prune --dry-run
+  brain attachments prune backend-api`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAttachmentsPrune,
+}
+
+func init() {
+	noteCmd.AddCommand(noteAttachCmd)
+	rootCmd.AddCommand(attachmentsCmd)
+	attachmentsCmd.AddCommand(attachmentsLsCmd)
+	attachmentsCmd.AddCommand(attachmentsPruneCmd)
+
+	attachmentsLsCmd.Flags().BoolVar(&attachmentsJSONFlag, "json", false, "Output JSON format")
+	attachmentsPruneCmd.Flags().BoolVar(&attachmentsDryRunFlag, "dry-run", false, "Show what would be deleted")
+}
+
+func runNoteAttach(cmd *cobra.Command, args []string) error {
+	srcPath := args[0]
+	if !fileutil.FileExists(srcPath) {
+		return fmt.Errorf("file not found: %s", srcPath)
+	}
+
+	projectDir, err := getFocusedProjectDir()
+	if err != nil {
+		return err
+	}
+
+	var noteRel string
+	if len(args) == 2 {
+		noteRel, err = api.ResolveNoteTarget(projectDir, args[1])
+	} else {
+		noteRel, err = selectExistingNote(projectDir, "Select note to attach to")
+	}
+	if err != nil {
+		if err.Error() == "cancelled" {
+			return nil
+		}
+		return err
+	}
+	notePath := filepath.Join(projectDir, noteRel)
+
+	name, reused, err := api.AddAttachment(projectDir, srcPath)
+	if err != nil {
+		return err
+	}
+
+	link := api.AttachmentLink(projectDir, notePath, name)
+	if err := api.AppendNoteLine(notePath, link); err != nil {
+		return fmt.Errorf("failed to link attachment: %w", err)
+	}
+
+	if reused {
+		fmt.Printf("OK: Linked existing attachment %s in %s\n", name, noteRel)
+	} else {
+		fmt.Printf("OK: Attached %s to %s\n", name, noteRel)
+	}
+	return nil
+}
+
+func runAttachmentsLs(cmd *cobra.Command, args []string) error {
+	projectDir, err := projectDirFromArgs(args)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := api.ListAttachments(projectDir)
+	if err != nil {
+		return err
+	}
+
+	if attachmentsJSONFlag {
+		data, err := json.MarshalIndent(attachments, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(attachments) == 0 {
+		fmt.Println("No attachments found")
+		return nil
+	}
+
+	for _, attachment := range attachments {
+		refs := "(unreferenced)"
+		if len(attachment.References) > 0 {
+			refs = strings.Join(attachment.References, ", ")
+		}
+		fmt.Printf("%-35s  %8s  %s\n", attachment.Name, formatSize(attachment.Size), refs)
+	}
+
+	return nil
+}
+
+func runAttachmentsPrune(cmd *cobra.Command, args []string) error {
+	projectDir, err := projectDirFromArgs(args)
+	if err != nil {
+		return err
+	}
+
+	pruned, err := api.PruneAttachments(projectDir, attachmentsDryRunFlag)
+	if err != nil {
+		return err
+	}
+
+	if len(pruned) == 0 {
+		fmt.Println("No unreferenced attachments")
+		return nil
+	}
+
+	var total int64
+	for _, attachment := range pruned {
+		total += attachment.Size
+		if attachmentsDryRunFlag {
+			fmt.Printf("Would delete: %s\n", attachment.Name)
+		} else {
+			fmt.Printf("Deleted: %s\n", attachment.Name)
+		}
+	}
+
+	if attachmentsDryRunFlag {
+		fmt.Printf("\nDry run: %d attachment(s), %s would be freed\n", len(pruned), formatSize(total))
+	} else {
+		fmt.Printf("\nOK: Pruned %d attachment(s), %s freed\n", len(pruned), formatSize(total))
+	}
+	return nil
+}
+
+// projectDirFromArgs resolves an optional project argument, defaulting to the focused project
+func projectDirFromArgs(args []string) (string, error) {
+	if len(args) == 0 {
+		return getFocusedProjectDir()
+	}
+
+	activeDir, err := getActiveDir()
+	if err != nil {
+		return "", err
+	}
+
+	return api.FindProjectDir(activeDir, args[0])
+}
+
+// selectExistingNote picks notes.md or a file in notes/ with fzf
+// Returns the note path relative to the project
+func selectExistingNote(projectDir, header string) (string, error) {
+	var options []string
+	if fileutil.FileExists(filepath.Join(projectDir, "notes.md")) {
+		options = append(options, "notes.md")
+	}
+
+	notes, err := api.ListNotes(projectDir)
+	if err != nil {
+		return "", err
+	}
+	for _, note := range notes {
+		options = append(options, filepath.Join("notes", note.Filename))
+	}
+
+	if len(options) == 0 {
+		return "", fmt.Errorf("no notes in project '%s'", filepath.Base(projectDir))
+	}
+
+	if !external.IsFZFAvailable() {
+		return "", fmt.Errorf("fzf not found (required for interactive mode). Pass the note as an argument")
+	}
+
+	return external.SelectOne(options, external.FZFOptions{
+		Header: header,
+		Prompt: "Note> ",
+		Height: "40%",
+	})
+}
+
+// formatSize renders a byte count as B, KB or MB
+func formatSize(size int64) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
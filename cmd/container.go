@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var containerJSONFlag bool
+
+var containerCmd = &cobra.Command{
+	Use:   "container",
+	Short: "Manage top-level project containers",
+	Long: `Manage the top-level directories that hold projects (PARA layout).
+
+01_active always holds active projects. Add containers for ongoing areas of
+responsibility or reference material, for example:
+
+  <brain>/
+  ├── 01_active/      # Projects with an end date
+  ├── 02_areas/       # Ongoing responsibilities (health, finances)
+  ├── 03_resources/   # Reference material
+  └── 99_archive/
+
+Projects in every container show up in project lists, todo lists, refile
+and 'brain go'. Move projects between containers with:
+
+  brain project move <project> --container <name>`,
+}
+
+var containerLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List containers",
+	Args:  cobra.NoArgs,
+	RunE:  runContainerLs,
+}
+
+var containerAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a container",
+	Long:  "Create a container directory numbered after the existing ones, e.g. 'areas' becomes 02_areas",
+	Example: `  brain container add areas
+  brain container add resources`,
+	Args: cobra.ExactArgs(1),
+	RunE: runContainerAdd,
+}
+
+func init() {
+	rootCmd.AddCommand(containerCmd)
+	containerCmd.AddCommand(containerLsCmd)
+	containerCmd.AddCommand(containerAddCmd)
+
+	containerLsCmd.Flags().BoolVar(&containerJSONFlag, "json", false, "Output JSON format")
+}
+
+func runContainerLs(cmd *cobra.Command, args []string) error {
+	brainPath, err := currentBrainPath()
+	if err != nil {
+		return err
+	}
+
+	containers, err := api.ListContainers(brainPath)
+	if err != nil {
+		return err
+	}
+
+	if containerJSONFlag {
+		data, err := json.MarshalIndent(containers, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	for _, container := range containers {
+		count := 0
+		if entries, err := os.ReadDir(container.Path); err == nil {
+			for _, entry := range entries {
+				if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+					count++
+				}
+			}
+		}
+		fmt.Printf("%-12s %-16s %d project(s)\n", container.Name, container.Dir, count)
+	}
+
+	return nil
+}
+
+func runContainerAdd(cmd *cobra.Command, args []string) error {
+	brainPath, err := currentBrainPath()
+	if err != nil {
+		return err
+	}
+
+	container, err := api.AddContainer(brainPath, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("OK: Created container: %s\n", container.Dir)
+	return nil
+}
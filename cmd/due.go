@@ -241,7 +241,7 @@ func promptAndSetDueDate(todo *api.TodoItem) error {
 
 // selectTodoNoDueDate selects a todo without a due date
 func selectTodoNoDueDate(activeDir string, prompt string) (*api.TodoItem, error) {
-	todos, err := api.ParseAllTodos(activeDir, false)
+	todos, err := loadTodos(activeDir, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse todos: %w", err)
 	}
@@ -4,14 +4,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/sandermoonemans/local-brain/pkg/api"
 	"github.com/sandermoonemans/local-brain/pkg/config"
+	"github.com/sandermoonemans/local-brain/pkg/dateutil"
 	"github.com/sandermoonemans/local-brain/pkg/fileutil"
 	"github.com/spf13/cobra"
 )
 
-var dumpJSONFlag bool
+var (
+	dumpJSONFlag      bool
+	dumpOlderThanFlag string
+	dumpSortFlag      string
+)
 
 var dumpCmd = &cobra.Command{
 	Use:   "dump",
@@ -19,11 +26,15 @@ var dumpCmd = &cobra.Command{
 	Long: `Display items in the dump file.
 
 Subcommands:
-  ls       List items in human-readable table format
-  ls --json  List items in JSON format for programmatic access`,
+  ls         List items in human-readable table format
+  ls --json  List items in JSON format for programmatic access
+  stats      Show how long items have been waiting
+  threshold  Show or set the number of days before items count as stale`,
 	Example: `  brain dump ls
+  brain dump ls --older-than 14d --sort age
   brain dump ls --json | jq '.[] | select(.type=="todo")'
-  brain dump ls --json | jq -r '.[].id'`,
+  brain dump ls --json | jq -r '.[].id'
+  brain dump stats`,
 }
 
 var dumpLsCmd = &cobra.Command{
@@ -32,18 +43,57 @@ var dumpLsCmd = &cobra.Command{
 	Long: `List all items in the dump file.
 
 Output formats:
-  Human-readable (default): Table with ID, type, and content
-  JSON (--json): Machine-readable array of objects`,
+  Human-readable (default): Table with ID, type, age, and content
+  JSON (--json): Machine-readable array of objects
+
+Filtering and sorting:
+  --older-than 14d  Only items captured more than 14 days ago (also 2w, 14)
+  --sort age        Oldest items first (undated items last)`,
 	Example: `  brain dump ls
-  brain dump ls --json`,
+  brain dump ls --json
+  brain dump ls --older-than 2w
+  brain dump ls --sort age`,
 	RunE: runDumpLs,
 }
 
+var dumpStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show dump aging statistics",
+	Long: `Show how many items are in the dump, the oldest item and an age histogram.
+
+Items are aged by their #captured: date. Items older than the brain's
+threshold (see 'brain dump threshold') are reported as stale.`,
+	Example: `  brain dump stats
+  brain dump stats --json`,
+	Args: cobra.NoArgs,
+	RunE: runDumpStats,
+}
+
+var dumpThresholdCmd = &cobra.Command{
+	Use:   "threshold [days]",
+	Short: "Show or set the stale threshold",
+	Long: `Show or set the number of days after which dump items count as stale.
+
+When items are older than the threshold, 'brain add' warns that the dump is
+overdue for processing and 'brain refile' presents the stalest items first.
+Set the threshold to 0 to disable this.`,
+	Example: `  brain dump threshold       # Show current threshold
+  brain dump threshold 14d   # Items older than two weeks are stale
+  brain dump threshold 0     # Disable aging warnings`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDumpThreshold,
+}
+
 func init() {
 	rootCmd.AddCommand(dumpCmd)
 	dumpCmd.AddCommand(dumpLsCmd)
+	dumpCmd.AddCommand(dumpStatsCmd)
+	dumpCmd.AddCommand(dumpThresholdCmd)
 
 	dumpLsCmd.Flags().BoolVar(&dumpJSONFlag, "json", false, "Output JSON format")
+	dumpLsCmd.Flags().StringVar(&dumpOlderThanFlag, "older-than", "", "Only show items captured more than this long ago (e.g. 14d, 2w)")
+	dumpLsCmd.Flags().StringVar(&dumpSortFlag, "sort", "", "Sort order: age (oldest first)")
+	dumpStatsCmd.Flags().BoolVar(&dumpJSONFlag, "json", false, "Output JSON format")
 }
 
 func runDumpLs(cmd *cobra.Command, args []string) error {
@@ -68,19 +118,37 @@ func runDumpLs(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	if dumpJSONFlag {
-		return outputJSON(dumpPath)
+	if dumpSortFlag != "" && dumpSortFlag != "age" {
+		return fmt.Errorf("invalid sort order '%s' (must be: age)", dumpSortFlag)
 	}
 
-	return outputTable(dumpPath)
-}
-
-func outputJSON(dumpPath string) error {
 	items, err := api.ParseDumpToJSON(dumpPath)
 	if err != nil {
 		return fmt.Errorf("failed to parse dump: %w", err)
 	}
 
+	now := time.Now()
+
+	if dumpOlderThanFlag != "" {
+		days, err := dateutil.ParseDays(dumpOlderThanFlag)
+		if err != nil {
+			return err
+		}
+		items = api.FilterDumpOlderThan(items, days, now)
+	}
+
+	if dumpSortFlag == "age" {
+		api.SortDumpByAge(items, now)
+	}
+
+	if dumpJSONFlag {
+		return outputJSON(items)
+	}
+
+	return outputTable(items, now)
+}
+
+func outputJSON(items []api.DumpItemJSON) error {
 	data, err := json.MarshalIndent(items, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
@@ -90,15 +158,10 @@ func outputJSON(dumpPath string) error {
 	return nil
 }
 
-func outputTable(dumpPath string) error {
-	items, err := api.ParseDumpToJSON(dumpPath)
-	if err != nil {
-		return fmt.Errorf("failed to parse dump: %w", err)
-	}
-
+func outputTable(items []api.DumpItemJSON, now time.Time) error {
 	// Print table header
-	fmt.Printf("%-8s | %-6s | %s\n", "ID", "Type", "Content")
-	fmt.Println("--------+--------+----------")
+	fmt.Printf("%-8s | %-8s | %-5s | %s\n", "ID", "Type", "Age", "Content")
+	fmt.Println("--------+----------+-------+----------")
 
 	if len(items) == 0 {
 		fmt.Println("(No items in dump)")
@@ -120,8 +183,137 @@ func outputTable(dumpPath string) error {
 			content = content[:57] + "..."
 		}
 
-		fmt.Printf("%-8s | %-6s | %s%s\n", item.ID, item.Type, content, suffix)
+		age := "-"
+		if days, ok := api.CapturedAge(item.Timestamp, now); ok {
+			age = fmt.Sprintf("%dd", days)
+		}
+
+		fmt.Printf("%-8s | %-8s | %-5s | %s%s\n", item.ID, item.Type, age, content, suffix)
+	}
+
+	return nil
+}
+
+func runDumpStats(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	brainPath, err := cfg.GetCurrentBrainPath()
+	if err != nil {
+		return fmt.Errorf("failed to get brain path: %w", err)
+	}
+
+	dumpPath := filepath.Join(brainPath, "00_dump.md")
+	if !fileutil.FileExists(dumpPath) {
+		return fmt.Errorf("dump not found at %s", dumpPath)
+	}
+
+	items, err := api.ParseDumpToJSON(dumpPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse dump: %w", err)
+	}
+
+	stats := api.ComputeDumpStats(items, cfg.GetDumpMaxAge(api.DefaultDumpMaxAge), time.Now())
+
+	if dumpJSONFlag {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("Items:  %d\n", stats.Count)
+	if stats.Count == 0 {
+		fmt.Println("Inbox zero!")
+		return nil
+	}
+
+	if stats.Oldest != nil {
+		fmt.Printf("Oldest: %dd - %s (%s)\n", stats.OldestAge, stats.Oldest.Content, stats.Oldest.ID)
+	}
+	if stats.MaxAge > 0 {
+		fmt.Printf("Stale:  %d older than %dd\n", stats.Stale, stats.MaxAge)
+	}
+
+	fmt.Println("\nAge histogram:")
+	maxCount := 0
+	for _, bucket := range stats.Histogram {
+		if bucket.Count > maxCount {
+			maxCount = bucket.Count
+		}
+	}
+	for _, bucket := range stats.Histogram {
+		bar := ""
+		if maxCount > 0 {
+			bar = strings.Repeat("#", (bucket.Count*30+maxCount-1)/maxCount)
+		}
+		fmt.Println(strings.TrimRight(fmt.Sprintf("  %-7s %3d %s", bucket.Label, bucket.Count, bar), " "))
+	}
+	if stats.Undated > 0 {
+		fmt.Printf("  %-7s %3d\n", "undated", stats.Undated)
+	}
+
+	return nil
+}
+
+func runDumpThreshold(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if len(args) == 0 {
+		days := cfg.GetDumpMaxAge(api.DefaultDumpMaxAge)
+		if days == 0 {
+			fmt.Println("Dump aging disabled")
+		} else {
+			fmt.Printf("%d days\n", days)
+		}
+		return nil
+	}
+
+	days, err := dateutil.ParseDays(args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.SetDumpMaxAge(days); err != nil {
+		return err
 	}
 
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if days == 0 {
+		fmt.Println("OK: Dump aging disabled")
+	} else {
+		fmt.Printf("OK: Dump items older than %d days are stale\n", days)
+	}
 	return nil
 }
+
+// warnStaleDump prints a reminder when dump items are older than the brain's threshold
+func warnStaleDump(cfg *config.Config, dumpPath string) {
+	maxAge := cfg.GetDumpMaxAge(api.DefaultDumpMaxAge)
+	if maxAge == 0 {
+		return
+	}
+
+	items, err := api.ParseDumpToJSON(dumpPath)
+	if err != nil {
+		return // Non-critical
+	}
+
+	stats := api.ComputeDumpStats(items, maxAge, time.Now())
+	if stats.Stale == 0 {
+		return
+	}
+
+	fmt.Printf("Warning: %d dump item(s) older than %d days (oldest: %dd). Run 'brain refile' to process them\n",
+		stats.Stale, maxAge, stats.OldestAge)
+}
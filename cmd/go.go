@@ -51,17 +51,15 @@ func runGo(cmd *cobra.Command, args []string) error {
 
 	activeDir := filepath.Join(brainPath, "01_active")
 
-	// Get list of projects
-	entries, err := os.ReadDir(activeDir)
+	// Get list of projects in every container
+	infos, err := api.ListProjects(activeDir, "")
 	if err != nil {
-		return fmt.Errorf("failed to read active directory: %w", err)
+		return err
 	}
 
 	var projects []string
-	for _, entry := range entries {
-		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
-			projects = append(projects, filepath.Join(activeDir, entry.Name()))
-		}
+	for _, info := range infos {
+		projects = append(projects, info.Path)
 	}
 
 	if len(projects) == 0 {
@@ -69,8 +67,14 @@ func runGo(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Select project with FZF
+	// Select project with FZF; the preview starts with project metadata
+	brainBin, err := os.Executable()
+	if err != nil {
+		brainBin = "brain"
+	}
+
 	previewCmd := `
+		'` + strings.ReplaceAll(brainBin, "'", `'\''`) + `' project show "$(basename {})" 2>/dev/null && echo ""
 		NOTES={}/notes.md
 		TODO={}/todo.md
 		echo " Notes "
@@ -115,7 +119,7 @@ func runGo(cmd *cobra.Command, args []string) error {
 	projectName := filepath.Base(projectDir)
 
 	// Get linked repos
-	repos, err := api.GetLinkedRepos(projectDir)
+	repos, err := linkedRepoPaths(projectDir)
 	if err != nil {
 		return fmt.Errorf("failed to get linked repos: %w", err)
 	}
@@ -206,8 +210,7 @@ func runTmuxEnvironment(projectDir, projectName string, repos []string) error {
 		return nil
 	}
 
-	// Session name (replace dots with underscores)
-	sessionName := "brain-" + strings.ReplaceAll(projectName, ".", "_")
+	sessionName := tmuxSessionName(projectName)
 
 	// Check if session already exists
 	if external.HasSession(sessionName) {
@@ -263,3 +266,8 @@ clear
 	// Attach to session
 	return external.AttachSession(sessionName)
 }
+
+// tmuxSessionName returns the tmux session for a project (dots replaced with underscores)
+func tmuxSessionName(projectName string) string {
+	return "brain-" + strings.ReplaceAll(projectName, ".", "_")
+}
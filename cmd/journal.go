@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/dateutil"
+	"github.com/sandermoonemans/local-brain/pkg/external"
+	"github.com/spf13/cobra"
+)
+
+var journalCmd = &cobra.Command{
+	Use:   "journal [date]",
+	Short: "Open the daily journal",
+	Long: `Open the journal note for a day, creating it if missing.
+
+Journal notes live in <brain>/journal/YYYY-MM-DD.md. A new journal is
+pre-filled with:
+  Due          Open tasks due that day (and overdue ones)
+  In Progress  Tasks currently in progress
+  Completed    Tasks completed that day (from their #done: date)
+  Log          Entries added with 'brain journal append'
+
+The date accepts the same formats as due dates: 2026-01-15, yesterday,
+-1w, monday, ... Defaults to today.`,
+	Example: `  brain journal              # Today's journal
+  brain journal yesterday
+  brain journal -1w          # A week ago
+  brain journal append "Deployed the new API"`,
+	Args: cobra.MaximumNArgs(1),
+	// Flag parsing is off so relative dates like -1w are read as arguments
+	DisableFlagParsing: true,
+	RunE:               runJournal,
+}
+
+var journalAppendCmd = &cobra.Command{
+	Use:   "append <text>",
+	Short: "Add a timestamped entry to today's journal",
+	Long: `Add a "- HH:MM text" entry to the Log section of today's journal
+without opening the editor. Creates the journal if missing.`,
+	Example: `  brain journal append "Deployed the new API"
+  brain journal append "Call with Sarah: agreed on scope"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runJournalAppend,
+}
+
+func init() {
+	rootCmd.AddCommand(journalCmd)
+	journalCmd.AddCommand(journalAppendCmd)
+}
+
+func runJournal(cmd *cobra.Command, args []string) error {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		return cmd.Help()
+	}
+
+	date := time.Now().Format("2006-01-02")
+	if len(args) == 1 {
+		parsed, err := dateutil.ParseNaturalDate(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid date: %w", err)
+		}
+		date = parsed
+	}
+
+	brainPath, err := currentBrainPath()
+	if err != nil {
+		return err
+	}
+
+	path, created, err := api.EnsureJournal(brainPath, date)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Printf("OK: Created journal for %s\n", date)
+	}
+
+	return external.OpenFile(path)
+}
+
+func runJournalAppend(cmd *cobra.Command, args []string) error {
+	brainPath, err := currentBrainPath()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	path, _, err := api.EnsureJournal(brainPath, now.Format("2006-01-02"))
+	if err != nil {
+		return err
+	}
+
+	if err := api.AppendJournalEntry(path, now.Format("15:04"), strings.Join(args, " ")); err != nil {
+		return fmt.Errorf("failed to append to journal: %w", err)
+	}
+
+	fmt.Println("OK: Added entry to journal")
+	return nil
+}
@@ -14,7 +14,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var noteJSONFlag bool
+var (
+	noteJSONFlag bool
+	noteTagFlag  []string
+)
 
 var noteCmd = &cobra.Command{
 	Use:   "note",
@@ -25,25 +28,45 @@ Interactive mode (no subcommand):
   Select and edit a note with fuzzy finder
 
 Subcommands:
-  ls       List all notes in focused project
-  delete   Delete a note
+  ls         List all notes in focused project
+  new        Create a note, optionally from a template
+  templates  List note templates
+  delete     Delete a note
+  links      Show wiki links from a note
+  backlinks  Show notes and tasks linking to a note
+  check      Report broken wiki links
+  rename     Rename a note and update links to it
+  attach     Attach a file to a note
 
 Notes are stored as separate files in project/notes/ directory.
-To create new notes, use: brain add → brain refile
+Create notes with: brain note new <title>, or brain add → brain refile
 
 Requires a focused project. Set with: brain project select <name>`,
 	Example: `  brain note               # Pick a note to edit
   brain note ls            # List all notes
   brain note ls --json     # List notes as JSON
-  brain note delete        # Pick a note to delete`,
+  brain note delete        # Pick a note to delete
+  brain note backlinks design-review`,
 	RunE: runNoteInteractive,
 }
 
 var noteLsCmd = &cobra.Command{
 	Use:   "ls",
 	Short: "List notes",
-	Long:  "List all notes in the focused project",
-	RunE:  runNoteLs,
+	Long: `List all notes in the focused project.
+
+Title, dates, tags and aliases are read from the note's front matter:
+  ---
+  title: Design review
+  created: 2024-01-15
+  tags: [architecture, api]
+  ---
+
+Notes without front matter use their "# Title" line and "Created:" date.`,
+	Example: `  brain note ls
+  brain note ls --tag architecture
+  brain note ls --json`,
+	RunE: runNoteLs,
 }
 
 var noteDeleteCmd = &cobra.Command{
@@ -59,6 +82,7 @@ func init() {
 	noteCmd.AddCommand(noteDeleteCmd)
 
 	noteLsCmd.Flags().BoolVar(&noteJSONFlag, "json", false, "Output JSON format")
+	noteLsCmd.Flags().StringSliceVar(&noteTagFlag, "tag", []string{}, "Filter by tag (can specify multiple)")
 }
 
 func runNoteLs(cmd *cobra.Command, args []string) error {
@@ -72,6 +96,10 @@ func runNoteLs(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list notes: %w", err)
 	}
 
+	if len(noteTagFlag) > 0 {
+		notes = filterNotesByTag(notes, noteTagFlag)
+	}
+
 	if len(notes) == 0 {
 		if noteJSONFlag {
 			fmt.Println("[]")
@@ -90,13 +118,31 @@ func runNoteLs(cmd *cobra.Command, args []string) error {
 	} else {
 		// Human-readable list
 		for _, note := range notes {
-			fmt.Printf("%-35s  %s\n", note.Filename, note.Title)
+			line := fmt.Sprintf("%-35s  %s", note.Filename, note.Title)
+			if len(note.Tags) > 0 {
+				line += " " + formatTags(note.Tags)
+			}
+			fmt.Println(line)
 		}
 	}
 
 	return nil
 }
 
+// filterNotesByTag keeps notes that have any of the given tags
+func filterNotesByTag(notes []api.NoteFile, tags []string) []api.NoteFile {
+	var filtered []api.NoteFile
+	for _, note := range notes {
+		for _, tag := range tags {
+			if note.HasTag(tag) {
+				filtered = append(filtered, note)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 func runNoteDelete(cmd *cobra.Command, args []string) error {
 	if !external.IsFZFAvailable() {
 		return fmt.Errorf("fzf not found (required for interactive mode)")
@@ -186,7 +232,7 @@ func runNoteInteractive(cmd *cobra.Command, args []string) error {
 
 	if len(notes) == 0 {
 		fmt.Println("No notes found in project")
-		fmt.Println("Create notes with: brain add → brain refile")
+		fmt.Println("Create notes with: brain note new <title>, or brain add → brain refile")
 		return nil
 	}
 
@@ -236,10 +282,9 @@ func getFocusedProjectDir() (string, error) {
 		return "", fmt.Errorf("failed to get brain path: %w", err)
 	}
 
-	projectDir := filepath.Join(brainPath, "01_active", focused)
-
-	if _, err := os.Stat(projectDir); os.IsNotExist(err) {
-		return "", fmt.Errorf("project directory not found: %s", projectDir)
+	projectDir, err := api.FindProjectDir(filepath.Join(brainPath, api.ActiveDirName), focused)
+	if err != nil {
+		return "", fmt.Errorf("project directory not found: %w", err)
 	}
 
 	return projectDir, nil
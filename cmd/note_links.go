@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var noteLinksJSONFlag bool
+
+var noteLinksCmd = &cobra.Command{
+	Use:   "links <note>",
+	Short: "Show wiki links from a note",
+	Long: `Show the [[wiki links]] in a note and where they point.
+
+A note is referenced by its file name without .md, its title or one of its
+aliases. Use project/note to pick a note in another project; bare names
+prefer the current project and otherwise must be unique across the brain.`,
+	Example: `  brain note links design-review
+  brain note links api/spec --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNoteLinks,
+}
+
+var noteBacklinksCmd = &cobra.Command{
+	Use:   "backlinks <note>",
+	Short: "Show notes and tasks linking to a note",
+	Long: `Show every [[wiki link]] pointing to a note.
+
+Links are collected from notes, notes.md and todo.md in all active projects.`,
+	Example: `  brain note backlinks design-review
+  brain note backlinks "Design review"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNoteBacklinks,
+}
+
+var noteCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Report broken wiki links",
+	Long: `Report [[wiki links]] that do not resolve to a note.
+
+Scans notes, notes.md and todo.md in all active projects.`,
+	Example: `  brain note check
+  brain note check --json`,
+	Args: cobra.NoArgs,
+	RunE: runNoteCheck,
+}
+
+var noteRenameCmd = &cobra.Command{
+	Use:   "rename <note> <new-name>",
+	Short: "Rename a note and update links to it",
+	Long: `Rename a note file and rewrite every [[wiki link]] that points to it by file name.
+
+Headings and labels are kept: [[old#Section|label]] becomes [[new#Section|label]].
+Links that match the note by title or alias keep working and are left alone.`,
+	Example: `  brain note rename design-review architecture-review
+  brain note rename api/spec api-spec`,
+	Args: cobra.ExactArgs(2),
+	RunE: runNoteRename,
+}
+
+func init() {
+	noteCmd.AddCommand(noteLinksCmd)
+	noteCmd.AddCommand(noteBacklinksCmd)
+	noteCmd.AddCommand(noteCheckCmd)
+	noteCmd.AddCommand(noteRenameCmd)
+
+	noteLinksCmd.Flags().BoolVar(&noteLinksJSONFlag, "json", false, "Output JSON format")
+	noteBacklinksCmd.Flags().BoolVar(&noteLinksJSONFlag, "json", false, "Output JSON format")
+	noteCheckCmd.Flags().BoolVar(&noteLinksJSONFlag, "json", false, "Output JSON format")
+}
+
+func runNoteLinks(cmd *cobra.Command, args []string) error {
+	activeDir, graph, note, err := loadLinkedNote(args[0])
+	if err != nil {
+		return err
+	}
+
+	links := graph.LinksFrom(note.Path)
+	if noteLinksJSONFlag {
+		return printLinksJSON(links)
+	}
+
+	if len(links) == 0 {
+		fmt.Printf("No links in %s\n", relToActive(activeDir, note.Path))
+		return nil
+	}
+
+	for _, link := range links {
+		target := "(broken)"
+		if link.Resolved != "" {
+			target = relToActive(activeDir, link.Resolved)
+		}
+		fmt.Printf("%4d  [[%s]] → %s\n", link.Line, link.Target, target)
+	}
+
+	return nil
+}
+
+func runNoteBacklinks(cmd *cobra.Command, args []string) error {
+	activeDir, graph, note, err := loadLinkedNote(args[0])
+	if err != nil {
+		return err
+	}
+
+	links := graph.Backlinks(note.Path)
+	if noteLinksJSONFlag {
+		return printLinksJSON(links)
+	}
+
+	if len(links) == 0 {
+		fmt.Printf("No backlinks to %s\n", relToActive(activeDir, note.Path))
+		return nil
+	}
+
+	for _, link := range links {
+		fmt.Printf("%s:%d  [[%s]]\n", relToActive(activeDir, link.SourcePath), link.Line, link.Target)
+	}
+
+	return nil
+}
+
+func runNoteCheck(cmd *cobra.Command, args []string) error {
+	activeDir, err := getActiveDir()
+	if err != nil {
+		return err
+	}
+
+	graph, err := api.BuildLinkGraph(activeDir)
+	if err != nil {
+		return err
+	}
+
+	broken := graph.BrokenLinks()
+	if noteLinksJSONFlag {
+		return printLinksJSON(broken)
+	}
+
+	if len(broken) == 0 {
+		fmt.Printf("OK: No broken links (%d links checked)\n", len(graph.Links))
+		return nil
+	}
+
+	for _, link := range broken {
+		fmt.Printf("%s:%d  [[%s]]\n", relToActive(activeDir, link.SourcePath), link.Line, link.Target)
+	}
+	fmt.Printf("\nWarning: %d broken link(s) found\n", len(broken))
+
+	return nil
+}
+
+func runNoteRename(cmd *cobra.Command, args []string) error {
+	activeDir, _, note, err := loadLinkedNote(args[0])
+	if err != nil {
+		return err
+	}
+
+	changed, err := api.RenameNote(activeDir, note, args[1])
+	if err != nil {
+		return fmt.Errorf("failed to rename note: %w", err)
+	}
+
+	newName := strings.TrimSuffix(args[1], ".md") + ".md"
+	fmt.Printf("OK: Renamed %s → %s\n", note.Filename, newName)
+	for _, path := range changed {
+		fmt.Printf("  Updated links in %s\n", relToActive(activeDir, path))
+	}
+
+	return nil
+}
+
+// loadLinkedNote builds the link graph and resolves a note reference from the current project
+func loadLinkedNote(query string) (string, *api.LinkGraph, *api.NoteFile, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	activeDir, err := getActiveDir()
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	graph, err := api.BuildLinkGraph(activeDir)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	note, err := graph.FindNote(query, currentProjectName(cfg, activeDir))
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return activeDir, graph, note, nil
+}
+
+// currentProjectName returns the project containing the working directory, or the focused project
+func currentProjectName(cfg *config.Config, activeDir string) string {
+	cwd, _ := os.Getwd()
+	if containers, err := api.ListContainers(filepath.Dir(activeDir)); err == nil {
+		for _, container := range containers {
+			if rel, err := filepath.Rel(container.Path, cwd); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+				project, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+				return project
+			}
+		}
+	}
+	return cfg.GetFocusedProject()
+}
+
+// relToActive shortens a path to project/... for display
+// Paths in other containers keep their container, e.g. 02_areas/health/notes.md
+func relToActive(activeDir, path string) string {
+	if rel, err := filepath.Rel(activeDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	if rel, err := filepath.Rel(filepath.Dir(activeDir), path); err == nil {
+		return rel
+	}
+	return path
+}
+
+func printLinksJSON(links []api.WikiLink) error {
+	if links == nil {
+		links = []api.WikiLink{}
+	}
+	data, err := json.MarshalIndent(links, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
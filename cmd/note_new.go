@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/config"
+	"github.com/sandermoonemans/local-brain/pkg/external"
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+	"github.com/sandermoonemans/local-brain/pkg/markdown"
+	"github.com/spf13/cobra"
+)
+
+var noteTemplateFlag string
+
+var noteNewCmd = &cobra.Command{
+	Use:   "new <title>",
+	Short: "Create a note in the focused project",
+	Long: `Create a note in the focused project's notes/ directory and open it in your editor.
+
+With --template, the note starts from <brain>/templates/notes/<name>.md.
+Templates can use these variables:
+  {{date}}     Today (YYYY-MM-DD)
+  {{time}}     Current time (HH:MM)
+  {{weekday}}  Day of the week
+  {{project}}  Focused project
+  {{title}}    Note title
+
+Front matter in the template is kept; title and dates are filled in when missing.
+#tags in the title become front matter tags.`,
+	Example: `  brain note new "Architecture ideas"
+  brain note new --template meeting "Sprint planning"
+  brain note new --template adr "Use SQLite for cache #decision"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runNoteNew,
+}
+
+var noteTemplatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List note templates",
+	Long:  "List the note templates in <brain>/templates/notes/",
+	Args:  cobra.NoArgs,
+	RunE:  runNoteTemplates,
+}
+
+func init() {
+	noteCmd.AddCommand(noteNewCmd)
+	noteCmd.AddCommand(noteTemplatesCmd)
+
+	noteNewCmd.Flags().StringVar(&noteTemplateFlag, "template", "", "Start from a note template")
+}
+
+func runNoteNew(cmd *cobra.Command, args []string) error {
+	brainPath, err := currentBrainPath()
+	if err != nil {
+		return err
+	}
+
+	projectDir, err := getFocusedProjectDir()
+	if err != nil {
+		return err
+	}
+
+	// #tags in the title become front matter tags
+	rawTitle := strings.TrimSpace(strings.Join(args, " "))
+	title, tags := markdown.ExtractTags(rawTitle)
+	if title == "" {
+		return fmt.Errorf("note title cannot be empty")
+	}
+
+	now := time.Now()
+	date := now.Format("2006-01-02")
+
+	content := api.NewNoteContent(title, date, tags, "")
+	if noteTemplateFlag != "" {
+		tmpl, err := api.LoadNoteTemplate(brainPath, noteTemplateFlag)
+		if err != nil {
+			return err
+		}
+		vars := api.NoteTemplateVars(title, filepath.Base(projectDir), now)
+		content = api.NoteTemplateContent(tmpl, title, date, tags, vars)
+	}
+
+	notesDir := filepath.Join(projectDir, "notes")
+	if err := fileutil.EnsureDir(notesDir); err != nil {
+		return fmt.Errorf("failed to create notes directory: %w", err)
+	}
+
+	slug := slugify(title)
+	if slug == "" {
+		slug = "note"
+	}
+	notePath := newNotePath(notesDir, date, slug)
+
+	if err := os.WriteFile(notePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to create note file: %w", err)
+	}
+
+	fmt.Printf("OK: Created note: %s\n", filepath.Base(notePath))
+	return external.OpenFile(notePath)
+}
+
+func runNoteTemplates(cmd *cobra.Command, args []string) error {
+	brainPath, err := currentBrainPath()
+	if err != nil {
+		return err
+	}
+
+	names, err := api.ListNoteTemplates(brainPath)
+	if err != nil {
+		return err
+	}
+
+	if len(names) == 0 {
+		fmt.Printf("No note templates found in %s\n", api.NoteTemplatesDir(brainPath))
+		return nil
+	}
+
+	for _, name := range names {
+		fmt.Println(name)
+	}
+	return nil
+}
+
+// currentBrainPath returns the path of the current brain
+func currentBrainPath() (string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+
+	brainPath, err := cfg.GetCurrentBrainPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to get brain path: %w", err)
+	}
+
+	return brainPath, nil
+}
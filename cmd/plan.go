@@ -49,7 +49,7 @@ func runPlan(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get all existing tags for suggestions
-	allTodos, err := api.ParseAllTodos(activeDir, false)
+	allTodos, err := loadTodos(activeDir, false)
 	if err != nil {
 		return fmt.Errorf("failed to parse todos: %w", err)
 	}
@@ -62,7 +62,7 @@ func runPlan(cmd *cobra.Command, args []string) error {
 	// Loop until user cancels (Esc in FZF)
 	for {
 		// Refresh todos to get latest state
-		todos, err := api.ParseAllTodos(activeDir, false)
+		todos, err := loadTodos(activeDir, false)
 		if err != nil {
 			return fmt.Errorf("failed to parse todos: %w", err)
 		}
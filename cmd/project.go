@@ -17,7 +17,16 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var projectJSONFlag bool
+var (
+	projectJSONFlag       bool
+	projectTemplateFlag   string
+	projectContainerFlag  string
+	projectLinkPathFlag   string
+	projectLinkBranchFlag string
+)
+
+// validProjectName restricts project names to safe directory names
+var validProjectName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
 var projectCmd = &cobra.Command{
 	Use:   "project",
@@ -38,8 +47,15 @@ var projectListCmd = &cobra.Command{
 var projectNewCmd = &cobra.Command{
 	Use:   "new <name>",
 	Short: "Create a new project",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runProjectNew,
+	Long: `Create a new project with notes.md, todo.md and .repos, and focus it.
+
+With --template, the project starts as a copy of <brain>/templates/projects/<name>/
+(see 'brain project templates'). Without it, the brain's default template is
+used if one is set; --template none forces the built-in files.`,
+	Example: `  brain project new billing
+  brain project new v2-launch --template release`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectNew,
 }
 
 var projectSelectCmd = &cobra.Command{
@@ -64,6 +80,8 @@ var projectCloneCmd = &cobra.Command{
 	Long: `Clone a git repository and set it up as a new project.
 
 Creates the project, links the repository, and pulls the code.`,
+	Example: `  brain project clone git@github.com:acme/api.git
+  brain project clone git@github.com:other/api.git other-api --path ~/work/other-api`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: runProjectClone,
 }
@@ -71,8 +89,14 @@ Creates the project, links the repository, and pulls the code.`,
 var projectLinkCmd = &cobra.Command{
 	Use:   "link <git-url>",
 	Short: "Link a git repository to current/focused project",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runProjectLink,
+	Long: `Add a git repository to the project's .repos file.
+
+The repository is checked out under the dev root (see 'brain project dev-root')
+unless --path is given. --branch is checked out when it is cloned.`,
+	Example: `  brain project link git@github.com:acme/api.git
+  brain project link git@github.com:acme/web.git --path ~/work/acme-web --branch main`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProjectLink,
 }
 
 var projectPullCmd = &cobra.Command{
@@ -84,15 +108,26 @@ var projectPullCmd = &cobra.Command{
 var projectArchiveCmd = &cobra.Command{
 	Use:   "archive <name>",
 	Short: "Archive a project",
-	Args:  cobra.MaximumNArgs(1),
-	RunE:  runProjectArchive,
+	Long: `Move a project from 01_active to 99_archive/<name>_<YYYYMMDD>.
+
+See archived projects with 'brain project archived' and bring one back with
+'brain project restore'.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runProjectArchive,
 }
 
 var projectMoveCmd = &cobra.Command{
 	Use:   "move <project> <target-brain>",
-	Short: "Move project to another brain",
-	Args:  cobra.RangeArgs(1, 2),
-	RunE:  runProjectMove,
+	Short: "Move project to another brain or container",
+	Long: `Move a project to another brain, or with --container to another container
+of the current brain (e.g. from 01_active to 02_areas).
+
+List containers with 'brain container ls'.`,
+	Example: `  brain project move website personal
+  brain project move health --container areas
+  brain project move health --container active`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runProjectMove,
 }
 
 var projectDeleteCmd = &cobra.Command{
@@ -117,6 +152,12 @@ func init() {
 	projectCmd.AddCommand(projectDeleteCmd)
 
 	projectListCmd.Flags().BoolVar(&projectJSONFlag, "json", false, "Output JSON format")
+	projectNewCmd.Flags().StringVar(&projectTemplateFlag, "template", "", "Create from a project template (none: built-in files)")
+	for _, c := range []*cobra.Command{projectLinkCmd, projectCloneCmd} {
+		c.Flags().StringVar(&projectLinkPathFlag, "path", "", "Check out the repository here instead of under the dev root")
+		c.Flags().StringVar(&projectLinkBranchFlag, "branch", "", "Branch to check out when cloning")
+	}
+	projectMoveCmd.Flags().StringVar(&projectContainerFlag, "container", "", "Move to a container of the current brain instead of another brain")
 }
 
 func runProjectList(cmd *cobra.Command, args []string) error {
@@ -147,13 +188,9 @@ func runProjectList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Human-readable output
-	fmt.Println("Active Projects:")
-	fmt.Println("----------------")
-
-	if len(projects) == 0 {
-		fmt.Println("(No active projects)")
-		return nil
+	containers, err := api.ListContainers(brainPath)
+	if err != nil {
+		return err
 	}
 
 	// Sort alphabetically
@@ -161,6 +198,45 @@ func runProjectList(cmd *cobra.Command, args []string) error {
 		return projects[i].Name < projects[j].Name
 	})
 
+	// Human-readable output, one section per container
+	for _, container := range containers {
+		var inContainer []api.ProjectInfo
+		for _, proj := range projects {
+			if proj.Container == container.Name {
+				inContainer = append(inContainer, proj)
+			}
+		}
+
+		if container.Dir == api.ActiveDirName {
+			fmt.Println("Active Projects:")
+			fmt.Println("----------------")
+			if len(inContainer) == 0 {
+				fmt.Println("(No active projects)")
+			}
+		} else {
+			if len(inContainer) == 0 {
+				continue
+			}
+			title := strings.ToUpper(container.Name[:1]) + container.Name[1:] + ":"
+			fmt.Println("")
+			fmt.Println(title)
+			fmt.Println(strings.Repeat("-", len(title)))
+		}
+
+		printProjectLines(inContainer)
+	}
+
+	fmt.Println("")
+	for _, proj := range projects {
+		if proj.MetaError != "" {
+			fmt.Printf("Warning: %s: %s\n", proj.Name, proj.MetaError)
+		}
+	}
+	return nil
+}
+
+// printProjectLines prints one project per line with repo and task counts
+func printProjectLines(projects []api.ProjectInfo) {
 	for _, proj := range projects {
 		marker := " "
 		status := ""
@@ -169,20 +245,24 @@ func runProjectList(cmd *cobra.Command, args []string) error {
 			status = "(selected)"
 		}
 
-		fmt.Printf(" %s %-20s %s [Repos: %d, Tasks: %d]\n",
-			marker, proj.Name, status, proj.RepoCount, proj.TaskCount)
-	}
+		details := projectListDetails(proj)
+		if details != "" {
+			details = " " + details
+		}
 
-	fmt.Println("")
-	return nil
+		fmt.Printf(" %s %-20s %s [Repos: %d, Tasks: %d]%s\n",
+			marker, proj.Name, status, proj.RepoCount, proj.TaskCount, details)
+		if proj.Description != "" {
+			fmt.Printf("     %s\n", proj.Description)
+		}
+	}
 }
 
 func runProjectNew(cmd *cobra.Command, args []string) error {
 	projectName := args[0]
 
 	// Validate project name
-	validName := regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
-	if !validName.MatchString(projectName) {
+	if !validProjectName.MatchString(projectName) {
 		return fmt.Errorf("project name can only contain letters, numbers, hyphens, and underscores")
 	}
 
@@ -197,55 +277,22 @@ func runProjectNew(cmd *cobra.Command, args []string) error {
 	}
 
 	activeDir := filepath.Join(brainPath, "01_active")
-	projectDir := filepath.Join(activeDir, projectName)
 
-	if fileutil.FileExists(projectDir) {
-		return fmt.Errorf("project '%s' already exists", projectName)
+	template := projectTemplateFlag
+	if template == "" {
+		template = cfg.GetDefaultProjectTemplate()
 	}
 
-	// Create project directory
-	if err := os.MkdirAll(projectDir, 0755); err != nil {
-		return fmt.Errorf("failed to create project directory: %w", err)
-	}
-
-	// Create notes.md
-	notesContent := fmt.Sprintf(`# %s
-
-Created: %s
-
-## Overview
-
-[Description]
-
-## Notes
-`, projectName, time.Now().Format("2006-01-02"))
-
-	if err := os.WriteFile(filepath.Join(projectDir, "notes.md"), []byte(notesContent), 0644); err != nil {
-		return fmt.Errorf("failed to create notes.md: %w", err)
-	}
-
-	// Create todo.md
-	todoContent := `# Tasks
-
-## Active
-
-- [ ] Define project goals
-- [ ] Set up development environment
-
-## Completed
-`
-
-	if err := os.WriteFile(filepath.Join(projectDir, "todo.md"), []byte(todoContent), 0644); err != nil {
-		return fmt.Errorf("failed to create todo.md: %w", err)
+	if err := api.CreateProject(brainPath, activeDir, projectName, template, time.Now()); err != nil {
+		return err
 	}
 
-	// Create empty .repos file
-	if err := os.WriteFile(filepath.Join(projectDir, ".repos"), []byte(""), 0644); err != nil {
-		return fmt.Errorf("failed to create .repos: %w", err)
+	if template != "" && template != api.NoProjectTemplate {
+		fmt.Printf("OK: Created project: %s (template: %s)\n", projectName, template)
+	} else {
+		fmt.Printf("OK: Created project: %s\n", projectName)
 	}
 
-	fmt.Printf("OK: Created project: %s\n", projectName)
-
 	// Auto-select the new project
 	if err := cfg.SetFocusedProject(projectName); err != nil {
 		return fmt.Errorf("failed to set focused project: %w", err)
@@ -280,16 +327,9 @@ func runProjectSelect(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("fzf not found (required for interactive mode)")
 		}
 
-		entries, err := os.ReadDir(activeDir)
+		projects, err := listProjects(activeDir)
 		if err != nil {
-			return fmt.Errorf("failed to read active directory: %w", err)
-		}
-
-		var projects []string
-		for _, entry := range entries {
-			if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
-				projects = append(projects, entry.Name())
-			}
+			return err
 		}
 
 		if len(projects) == 0 {
@@ -314,9 +354,8 @@ func runProjectSelect(cmd *cobra.Command, args []string) error {
 	}
 
 	// Verify project exists
-	projectDir := filepath.Join(activeDir, projectName)
-	if !fileutil.FileExists(projectDir) {
-		return fmt.Errorf("project '%s' not found", projectName)
+	if _, err := api.FindProjectDir(activeDir, projectName); err != nil {
+		return err
 	}
 
 	// Set focused project
@@ -408,7 +447,7 @@ func runProjectLink(cmd *cobra.Command, args []string) error {
 	}
 
 	// Add to .repos file
-	if err := api.AddRepoLink(projectDir, gitURL); err != nil {
+	if err := api.AddRepoLink(projectDir, gitURL, projectLinkPathFlag, projectLinkBranchFlag); err != nil {
 		return fmt.Errorf("failed to link repository: %w", err)
 	}
 
@@ -432,12 +471,7 @@ func runProjectPull(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Project: %s\n", projectName)
 
-	devDir := filepath.Join(os.Getenv("HOME"), "dev")
-	if err := fileutil.EnsureDir(devDir); err != nil {
-		return fmt.Errorf("failed to create dev directory: %w", err)
-	}
-
-	repos, err := api.GetLinkedRepos(projectDir)
+	repos, err := loadLinkedRepos(projectDir)
 	if err != nil {
 		return fmt.Errorf("failed to get linked repos: %w", err)
 	}
@@ -447,36 +481,19 @@ func runProjectPull(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Read .repos file for URLs
-	reposFile := filepath.Join(projectDir, ".repos")
-	data, err := os.ReadFile(reposFile)
-	if err != nil {
-		return fmt.Errorf("failed to read .repos: %w", err)
-	}
-
-	lines := strings.Split(string(data), "\n")
-	for _, gitURL := range lines {
-		gitURL = strings.TrimSpace(gitURL)
-		if gitURL == "" || strings.HasPrefix(gitURL, "#") {
-			continue
-		}
-
-		repoName := api.ExtractRepoName(gitURL)
-		if repoName == "" {
-			continue
-		}
-
-		repoPath := filepath.Join(devDir, repoName)
-		fmt.Printf("  %s\n", repoName)
+	for _, repo := range repos {
+		fmt.Printf("  %s -> %s\n", repo.Name(), repo.Path)
 
-		if fileutil.FileExists(repoPath) {
+		if fileutil.FileExists(repo.Path) {
 			fmt.Println("    Updating...")
-			if err := external.Pull(repoPath); err != nil {
+			if err := external.Pull(repo.Path); err != nil {
 				fmt.Printf("    ERROR: Failed update: %v\n", err)
 			}
 		} else {
 			fmt.Println("    Cloning...")
-			if err := external.Clone(gitURL, repoPath); err != nil {
+			if err := fileutil.EnsureDir(filepath.Dir(repo.Path)); err != nil {
+				fmt.Printf("    ERROR: Failed to create %s: %v\n", filepath.Dir(repo.Path), err)
+			} else if err := external.Clone(repo.URL, repo.Path, repo.Branch); err != nil {
 				fmt.Printf("    ERROR: Failed clone: %v\n", err)
 			}
 		}
@@ -509,12 +526,8 @@ func runProjectArchive(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get brain path: %w", err)
 	}
 
-	activeDir := filepath.Join(brainPath, "01_active")
-	archiveDir := filepath.Join(brainPath, "99_archive")
-	projectDir := filepath.Join(activeDir, projectName)
-
-	if !fileutil.FileExists(projectDir) {
-		return fmt.Errorf("project '%s' not found", projectName)
+	if _, err := api.FindProjectDir(filepath.Join(brainPath, api.ActiveDirName), projectName); err != nil {
+		return err
 	}
 
 	// Clear focus if archiving focused project
@@ -527,22 +540,12 @@ func runProjectArchive(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Create archive directory
-	if err := fileutil.EnsureDir(archiveDir); err != nil {
-		return fmt.Errorf("failed to create archive directory: %w", err)
-	}
-
-	// Create archive name with timestamp
-	timestamp := time.Now().Format("20060102")
-	archiveName := fmt.Sprintf("%s_%s", projectName, timestamp)
-	archivePath := filepath.Join(archiveDir, archiveName)
-
-	// Move project
-	if err := os.Rename(projectDir, archivePath); err != nil {
-		return fmt.Errorf("failed to archive project: %w", err)
+	archivedDir, err := api.ArchiveProject(brainPath, projectName, time.Now())
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("OK: Archived: %s\n", projectName)
+	fmt.Printf("OK: Archived: %s (%s/%s)\n", projectName, api.ArchiveDirName, archivedDir)
 	return nil
 }
 
@@ -555,6 +558,13 @@ func runProjectMove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	if projectContainerFlag != "" {
+		if len(args) > 1 {
+			return fmt.Errorf("--container moves within the current brain; drop the target brain")
+		}
+		return moveProjectToContainer(cfg, projectName, projectContainerFlag)
+	}
+
 	if len(args) > 1 {
 		targetBrain = args[1]
 	} else {
@@ -608,17 +618,23 @@ func runProjectMove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get target brain path: %w", err)
 	}
 
-	currentPath := filepath.Join(brainPath, "01_active", projectName)
-	targetPath := filepath.Join(targetBrainPath, "01_active", projectName)
-
-	if !fileutil.FileExists(currentPath) {
+	currentPath, err := api.FindProjectDir(filepath.Join(brainPath, api.ActiveDirName), projectName)
+	if err != nil {
 		return fmt.Errorf("project '%s' not found in current brain", projectName)
 	}
 
-	if fileutil.FileExists(targetPath) {
+	// Keep the project in the same container, e.g. 02_areas stays 02_areas
+	targetContainer := filepath.Join(targetBrainPath, filepath.Base(filepath.Dir(currentPath)))
+	targetPath := filepath.Join(targetContainer, projectName)
+
+	if _, err := api.FindProjectDir(filepath.Join(targetBrainPath, api.ActiveDirName), projectName); err == nil {
 		return fmt.Errorf("project '%s' already exists in '%s'", projectName, targetBrain)
 	}
 
+	if err := fileutil.EnsureDir(targetContainer); err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+
 	// Move
 	fmt.Printf("Moving '%s' to '%s'...\n", projectName, targetBrain)
 	if err := os.Rename(currentPath, targetPath); err != nil {
@@ -639,6 +655,26 @@ func runProjectMove(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// moveProjectToContainer moves a project between containers of the current brain
+func moveProjectToContainer(cfg *config.Config, projectName, containerName string) error {
+	brainPath, err := cfg.GetCurrentBrainPath()
+	if err != nil {
+		return fmt.Errorf("failed to get brain path: %w", err)
+	}
+
+	container, err := api.FindContainer(brainPath, containerName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := api.MoveProject(filepath.Join(brainPath, api.ActiveDirName), projectName, container.Dir); err != nil {
+		return err
+	}
+
+	fmt.Printf("OK: Moved %s to %s\n", projectName, container.Dir)
+	return nil
+}
+
 func runProjectDelete(cmd *cobra.Command, args []string) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -661,10 +697,9 @@ func runProjectDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get brain path: %w", err)
 	}
 
-	projectDir := filepath.Join(brainPath, "01_active", projectName)
-
-	if !fileutil.FileExists(projectDir) {
-		return fmt.Errorf("project '%s' not found", projectName)
+	projectDir, err := api.FindProjectDir(filepath.Join(brainPath, api.ActiveDirName), projectName)
+	if err != nil {
+		return err
 	}
 
 	// Warning
@@ -713,16 +748,21 @@ func resolveTargetProject(cfg *config.Config, actionDesc string) (string, string
 
 	// Check PWD first
 	cwd, _ := os.Getwd()
-	if strings.HasPrefix(cwd, activeDir+string(filepath.Separator)) {
-		projectName := filepath.Base(cwd)
-		return projectName, cwd, nil
+	containers, err := api.ListContainers(brainPath)
+	if err != nil {
+		return "", "", err
+	}
+	for _, container := range containers {
+		if strings.HasPrefix(cwd, container.Path+string(filepath.Separator)) {
+			projectName := filepath.Base(cwd)
+			return projectName, cwd, nil
+		}
 	}
 
 	// Check focused project
 	focused := cfg.GetFocusedProject()
 	if focused != "" {
-		projectDir := filepath.Join(activeDir, focused)
-		if fileutil.FileExists(projectDir) {
+		if projectDir, err := api.FindProjectDir(activeDir, focused); err == nil {
 			return focused, projectDir, nil
 		}
 	}
@@ -732,16 +772,9 @@ func resolveTargetProject(cfg *config.Config, actionDesc string) (string, string
 		return "", "", fmt.Errorf("cannot resolve project. Install fzf for interactive selection or use 'brain project select <name>'")
 	}
 
-	entries, err := os.ReadDir(activeDir)
+	projects, err := listProjects(activeDir)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to read active directory: %w", err)
-	}
-
-	var projects []string
-	for _, entry := range entries {
-		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
-			projects = append(projects, entry.Name())
-		}
+		return "", "", err
 	}
 
 	if len(projects) == 0 {
@@ -760,6 +793,9 @@ func resolveTargetProject(cfg *config.Config, actionDesc string) (string, string
 		return "", "", err
 	}
 
-	projectDir := filepath.Join(activeDir, selected)
+	projectDir, err := api.FindProjectDir(activeDir, selected)
+	if err != nil {
+		return "", "", err
+	}
 	return selected, projectDir, nil
 }
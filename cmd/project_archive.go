@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/config"
+	"github.com/sandermoonemans/local-brain/pkg/external"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectArchivedJSONFlag bool
+	projectRestoreAsFlag    string
+	todoArchivedFlag        bool
+)
+
+var projectArchivedCmd = &cobra.Command{
+	Use:   "archived [query]",
+	Short: "List archived projects",
+	Long: `List projects in 99_archive, most recently archived first.
+
+The optional query filters by project name, description and area
+(case-insensitive substring).`,
+	Example: `  brain project archived
+  brain project archived billing
+  brain project archived --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runProjectArchived,
+}
+
+var projectRestoreCmd = &cobra.Command{
+	Use:   "restore [name]",
+	Short: "Restore an archived project",
+	Long: `Move an archived project back to 01_active.
+
+The name is the original project name or the archive directory name
+(e.g. billing_20240115); the date suffix is dropped on restore. If a project
+was archived more than once, use the directory name. If an active project
+already has the name, restore under another one with --as.
+
+Without a name, pick the project with fzf.`,
+	Example: `  brain project restore billing
+  brain project restore billing_20240115 --as billing-v1
+  brain project restore`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runProjectRestore,
+}
+
+func init() {
+	projectCmd.AddCommand(projectArchivedCmd)
+	projectCmd.AddCommand(projectRestoreCmd)
+
+	projectArchivedCmd.Flags().BoolVar(&projectArchivedJSONFlag, "json", false, "Output JSON format")
+	projectRestoreCmd.Flags().StringVar(&projectRestoreAsFlag, "as", "", "Restore under a different project name")
+	todoLsCmd.Flags().BoolVar(&todoArchivedFlag, "archived", false, "Include tasks from archived projects")
+}
+
+func runProjectArchived(cmd *cobra.Command, args []string) error {
+	brainPath, err := currentBrainPath()
+	if err != nil {
+		return err
+	}
+
+	projects, err := api.ListArchivedProjects(brainPath)
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		query := strings.ToLower(args[0])
+		var matched []api.ArchivedProject
+		for _, project := range projects {
+			text := strings.ToLower(project.Dir + " " + project.Description + " " + project.Area)
+			if strings.Contains(text, query) {
+				matched = append(matched, project)
+			}
+		}
+		projects = matched
+	}
+
+	if projectArchivedJSONFlag {
+		if projects == nil {
+			projects = []api.ArchivedProject{}
+		}
+		data, err := json.MarshalIndent(projects, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(projects) == 0 {
+		fmt.Println("No archived projects found")
+		return nil
+	}
+
+	fmt.Println("Archived Projects:")
+	fmt.Println("------------------")
+	for _, project := range projects {
+		archivedOn := project.ArchivedOn
+		if archivedOn == "" {
+			archivedOn = "-"
+		}
+		fmt.Printf("  %-20s %-10s [Open tasks: %d]  %s\n", project.Name, archivedOn, project.TaskCount, project.Dir)
+		if project.Description != "" {
+			fmt.Printf("      %s\n", project.Description)
+		}
+	}
+	fmt.Println("")
+	for _, project := range projects {
+		if project.MetaError != "" {
+			fmt.Printf("Warning: %s: %s\n", project.Dir, project.MetaError)
+		}
+	}
+
+	return nil
+}
+
+func runProjectRestore(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	brainPath, err := cfg.GetCurrentBrainPath()
+	if err != nil {
+		return fmt.Errorf("failed to get brain path: %w", err)
+	}
+
+	var query string
+	if len(args) > 0 {
+		query = args[0]
+	} else {
+		query, err = selectArchivedProject(brainPath)
+		if err != nil {
+			if err.Error() == "cancelled" {
+				return nil
+			}
+			return err
+		}
+	}
+
+	project, err := api.FindArchivedProject(brainPath, query)
+	if err != nil {
+		return err
+	}
+
+	if projectRestoreAsFlag != "" && !validProjectName.MatchString(projectRestoreAsFlag) {
+		return fmt.Errorf("project name can only contain letters, numbers, hyphens, and underscores")
+	}
+
+	name, err := api.RestoreProject(brainPath, project, projectRestoreAsFlag)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("OK: Restored %s as %s\n", project.Dir, name)
+	fmt.Printf("Focus it with: brain project select %s\n", name)
+	return nil
+}
+
+// selectArchivedProject picks an archived project directory with fzf
+func selectArchivedProject(brainPath string) (string, error) {
+	projects, err := api.ListArchivedProjects(brainPath)
+	if err != nil {
+		return "", err
+	}
+	if len(projects) == 0 {
+		return "", fmt.Errorf("no archived projects")
+	}
+
+	if !external.IsFZFAvailable() {
+		return "", fmt.Errorf("fzf not found (required for interactive mode). Pass the project name as an argument")
+	}
+
+	options := make([]string, len(projects))
+	for i, project := range projects {
+		options[i] = project.Dir
+	}
+
+	return external.SelectOne(options, external.FZFOptions{
+		Header: "Select project to restore",
+		Prompt: "Archive> ",
+	})
+}
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/config"
+	"github.com/sandermoonemans/local-brain/pkg/external"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectSplitFilterFlag []string
+	projectSplitDryRunFlag bool
+	projectUndoForceFlag   bool
+)
+
+var projectMergeCmd = &cobra.Command{
+	Use:   "merge <src> <dst>",
+	Short: "Merge a project into another",
+	Long: `Merge project <src> into <dst> and remove <src>.
+
+  todo.md      Tasks are appended to the section with the same heading
+  notes.md     Appended under a "From <src>" heading
+  notes/       Moved; name collisions get a -2 suffix
+  attachments/ Moved; identical files are reused, collisions get a hash suffix
+  .repos       Unioned
+  .project.json  <dst> keeps its metadata
+
+[[src/note]] links, +src routing in the dump, refile-rules targets and refile
+history are rewritten to <dst>. Undo with 'brain project undo'.`,
+	Example: `  brain project merge website-redesign website`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    runProjectMerge,
+}
+
+var projectSplitCmd = &cobra.Command{
+	Use:   "split <src> <new>",
+	Short: "Move tasks and notes into a new project",
+	Long: `Move selected tasks and notes of <src> into a new project <new>.
+
+Select with fzf (multi-select with Tab) or with --filter expressions using
+the refile rule syntax; an item moves if it matches any filter:
+
+  tag:<tag>         Tasks or notes with #tag
+  keyword:<text>    Text contains <text> (case-insensitive)
+  regex:<pattern>   Text matches the regular expression
+
+Tasks move with their indented subtasks and keep their todo.md section. The
+new project gets <src>'s .repos and the attachments the moved content links
+to. Links to moved notes are rewritten. Undo with 'brain project undo'.`,
+	Example: `  brain project split website frontend --filter tag:frontend
+  brain project split website frontend --filter tag:css --filter keyword:design --dry-run
+  brain project split website frontend     # Pick tasks and notes with fzf`,
+	Args: cobra.ExactArgs(2),
+	RunE: runProjectSplit,
+}
+
+var projectUndoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the last project merge or split",
+	Long: `Restore the projects and files changed by the most recent merge or split.
+
+Undo restores the copies taken before the change. It refuses if the projects
+or the files whose links were rewritten (dump, journal, other projects,
+refile-rules) were edited since, as those edits would be lost; --force undoes
+anyway. The last 10 merges and splits can be undone, newest first.`,
+	Args: cobra.NoArgs,
+	RunE: runProjectUndo,
+}
+
+func init() {
+	projectCmd.AddCommand(projectMergeCmd)
+	projectCmd.AddCommand(projectSplitCmd)
+	projectCmd.AddCommand(projectUndoCmd)
+
+	projectSplitCmd.Flags().StringArrayVar(&projectSplitFilterFlag, "filter", nil, "Select items matching tag:, keyword: or regex: (repeatable)")
+	projectSplitCmd.Flags().BoolVar(&projectSplitDryRunFlag, "dry-run", false, "Show what would be moved")
+	projectUndoCmd.Flags().BoolVar(&projectUndoForceFlag, "force", false, "Undo even if files were edited since, discarding those edits")
+}
+
+func runProjectMerge(cmd *cobra.Command, args []string) error {
+	src, dst := args[0], args[1]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	brainPath, err := cfg.GetCurrentBrainPath()
+	if err != nil {
+		return fmt.Errorf("failed to get brain path: %w", err)
+	}
+
+	change, err := api.MergeProjects(brainPath, src, dst, cfg.GetFocusedProject(), time.Now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("OK: Merged %s into %s (%d task(s), %d note(s))\n", src, dst, change.Tasks, change.Notes)
+	printRenamed(change)
+	if len(change.Files) > 0 {
+		fmt.Printf("  Updated references in %d file(s)\n", len(change.Files))
+	}
+
+	if change.Focus != nil {
+		if err := cfg.SetFocusedProject(dst); err != nil {
+			return fmt.Errorf("failed to update focus: %w", err)
+		}
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Printf("OK: Selected project: %s\n", dst)
+	}
+
+	fmt.Println("Undo with: brain project undo")
+	return nil
+}
+
+func runProjectSplit(cmd *cobra.Command, args []string) error {
+	src, newName := args[0], args[1]
+
+	if !validProjectName.MatchString(newName) {
+		return fmt.Errorf("project name can only contain letters, numbers, hyphens, and underscores")
+	}
+
+	brainPath, err := currentBrainPath()
+	if err != nil {
+		return err
+	}
+
+	srcDir, err := api.FindProjectDir(filepath.Join(brainPath, api.ActiveDirName), src)
+	if err != nil {
+		return err
+	}
+
+	items, err := api.SplitCandidates(srcDir)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("project '%s' has no tasks or notes", src)
+	}
+
+	var selected []api.SplitItem
+	if len(projectSplitFilterFlag) > 0 {
+		selected, err = api.FilterSplitItems(items, projectSplitFilterFlag)
+		if err != nil {
+			return err
+		}
+	} else {
+		selected, err = selectSplitItems(items)
+		if err != nil {
+			if err.Error() == "cancelled" {
+				return nil
+			}
+			return err
+		}
+	}
+
+	if len(selected) == 0 {
+		fmt.Println("Nothing selected")
+		return nil
+	}
+
+	if projectSplitDryRunFlag {
+		fmt.Printf("Would move to %s:\n", newName)
+		for _, item := range selected {
+			fmt.Printf("  %s\n", item.Label)
+		}
+		return nil
+	}
+
+	change, err := api.SplitProject(brainPath, src, newName, selected, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("OK: Created project %s with %d task(s) and %d note(s) from %s\n", newName, change.Tasks, change.Notes, src)
+	if len(change.Files) > 0 {
+		fmt.Printf("  Updated references in %d file(s)\n", len(change.Files))
+	}
+	fmt.Println("Undo with: brain project undo")
+	return nil
+}
+
+func runProjectUndo(cmd *cobra.Command, args []string) error {
+	brainPath, err := currentBrainPath()
+	if err != nil {
+		return err
+	}
+
+	change, err := api.UndoProjectChange(brainPath, projectUndoForceFlag)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("OK: Undid %s (%s)\n", change.Summary, change.Date)
+
+	// Move focus back if the change moved it
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if focus, ok := change.FocusAfterUndo(cfg.GetFocusedProject()); ok {
+		if err := cfg.SetFocusedProject(focus); err != nil {
+			return fmt.Errorf("failed to update focus: %w", err)
+		}
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Printf("OK: Selected project: %s\n", focus)
+	}
+	return nil
+}
+
+// selectSplitItems picks tasks and notes with fzf multi-select
+func selectSplitItems(items []api.SplitItem) ([]api.SplitItem, error) {
+	if !external.IsFZFAvailable() {
+		return nil, fmt.Errorf("fzf not found (required for interactive mode). Use --filter")
+	}
+
+	byLabel := make(map[string]api.SplitItem)
+	var options []string
+	for _, item := range items {
+		label := fmt.Sprintf("%-5s %s", item.Kind, item.Label)
+		if item.Kind == "task" {
+			label = fmt.Sprintf("%-5s %s  (line %d)", item.Kind, item.Label, item.Line)
+		}
+		byLabel[label] = item
+		options = append(options, label)
+	}
+
+	chosen, err := external.Select(options, external.FZFOptions{
+		Header: "Select tasks and notes to move (Tab to mark)",
+		Prompt: "Move> ",
+		Multi:  true,
+		NoSort: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []api.SplitItem
+	for _, label := range chosen {
+		if item, ok := byLabel[label]; ok {
+			selected = append(selected, item)
+		}
+	}
+	return selected, nil
+}
+
+// printRenamed lists notes and attachments renamed to avoid collisions
+func printRenamed(change *api.ProjectChange) {
+	names := make([]string, 0, len(change.Renamed))
+	for from := range change.Renamed {
+		names = append(names, from)
+	}
+	sort.Strings(names)
+	for _, from := range names {
+		fmt.Printf("  Renamed %s -> %s\n", from, change.Renamed[from])
+	}
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var projectShowJSONFlag bool
+
+var projectSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set project metadata",
+	Long: `Set a metadata field of the current/focused project.
+
+Metadata is stored in .project.json in the project directory.
+
+Keys:
+  description  One-line summary
+  status       active, on-hold or someday
+  area         Area of responsibility the project belongs to
+  owner        Person responsible
+  start        Start date (YYYY-MM-DD, today, +1w, monday, ...)
+  target       Target date (same formats as start)
+  links        Comma-separated URLs (replaces existing links)
+
+Pass an empty value ("") to clear a field.`,
+	Example: `  brain project set description "Rewrite of the billing frontend"
+  brain project set status on-hold
+  brain project set target +6w
+  brain project set links "https://github.com/acme/web, https://acme.atlassian.net/browse/WEB"
+  brain project set owner ""`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runProjectSet,
+}
+
+var projectShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show project details",
+	Long:  "Show a project's metadata, task and repo counts (default: focused project)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runProjectShow,
+}
+
+func init() {
+	projectCmd.AddCommand(projectSetCmd)
+	projectCmd.AddCommand(projectShowCmd)
+
+	projectShowCmd.Flags().BoolVar(&projectShowJSONFlag, "json", false, "Output JSON format")
+}
+
+func runProjectSet(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	projectName, projectDir, err := resolveTargetProject(cfg, "set metadata")
+	if err != nil {
+		return err
+	}
+
+	key := strings.ToLower(args[0])
+	value := strings.Join(args[1:], " ")
+
+	meta, err := api.SetProjectMeta(projectDir, key, value)
+	if err != nil {
+		return err
+	}
+
+	display := projectMetaValue(meta, key)
+	if display == "" {
+		fmt.Printf("OK: Cleared %s of %s\n", key, projectName)
+	} else {
+		fmt.Printf("OK: Set %s of %s: %s\n", key, projectName, display)
+	}
+	return nil
+}
+
+func runProjectShow(cmd *cobra.Command, args []string) error {
+	projectDir, err := projectDirFromArgs(args)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	projects, err := api.ListProjects(filepath.Dir(projectDir), cfg.GetFocusedProject())
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	var project *api.ProjectInfo
+	for i := range projects {
+		if projects[i].Name == filepath.Base(projectDir) {
+			project = &projects[i]
+			break
+		}
+	}
+	if project == nil {
+		return fmt.Errorf("project '%s' not found", filepath.Base(projectDir))
+	}
+
+	if projectShowJSONFlag {
+		data, err := json.MarshalIndent(project, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if project.MetaError != "" {
+		fmt.Printf("Warning: %s\n", project.MetaError)
+	}
+	fmt.Printf("Project: %s\n", project.Name)
+	if project.Description != "" {
+		fmt.Printf("  %s\n", project.Description)
+	}
+	fmt.Println("")
+	for _, key := range []string{"status", "area", "owner", "start", "target"} {
+		if value := projectMetaValue(project.ProjectMeta, key); value != "" {
+			fmt.Printf("%-8s %s\n", strings.ToUpper(key[:1])+key[1:]+":", value)
+		}
+	}
+	fmt.Printf("%-8s %d not done\n", "Tasks:", project.TaskCount)
+	fmt.Printf("%-8s %d\n", "Repos:", project.RepoCount)
+
+	if len(project.Links) > 0 {
+		fmt.Println("Links:")
+		for _, link := range project.Links {
+			fmt.Printf("  - %s\n", link)
+		}
+	}
+
+	return nil
+}
+
+// projectMetaValue returns a metadata field as display text
+func projectMetaValue(meta api.ProjectMeta, key string) string {
+	switch key {
+	case "description":
+		return meta.Description
+	case "status":
+		return meta.Status
+	case "area":
+		return meta.Area
+	case "owner":
+		return meta.Owner
+	case "start":
+		return meta.Start
+	case "target":
+		return meta.Target
+	case "links":
+		return strings.Join(meta.Links, ", ")
+	}
+	return ""
+}
+
+// projectListDetails summarizes non-default metadata for project list
+func projectListDetails(project api.ProjectInfo) string {
+	var details []string
+	if project.Status != "" && project.Status != api.ProjectStatusActive {
+		details = append(details, project.Status)
+	}
+	if project.Area != "" {
+		details = append(details, "area: "+project.Area)
+	}
+	if project.Owner != "" {
+		details = append(details, "owner: "+project.Owner)
+	}
+	if project.Target != "" {
+		details = append(details, "target: "+project.Target)
+	}
+	return strings.Join(details, ", ")
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/config"
+	"github.com/sandermoonemans/local-brain/pkg/external"
+	"github.com/spf13/cobra"
+)
+
+var projectRenameCmd = &cobra.Command{
+	Use:   "rename <old-name> <new-name>",
+	Short: "Rename a project",
+	Long: `Rename a project and update everything that refers to it.
+
+What it does:
+  1. Renames the project directory (it stays in its container)
+  2. Rewrites [[old-name/note]] links in notes, task files, the dump and the journal
+  3. Rewrites +old-name routing in the dump, refile-rules targets and refile history
+  4. Updates the focused project
+  5. Renames a running 'brain-<project>' tmux session
+
+Refuses if a project with the new name already exists.`,
+	Example: `  brain project rename website marketing-site`,
+	Args:    cobra.ExactArgs(2),
+	RunE:    runProjectRename,
+}
+
+func init() {
+	projectCmd.AddCommand(projectRenameCmd)
+}
+
+func runProjectRename(cmd *cobra.Command, args []string) error {
+	oldName, newName := args[0], args[1]
+
+	if !validProjectName.MatchString(newName) {
+		return fmt.Errorf("project name can only contain letters, numbers, hyphens, and underscores")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	brainPath, err := cfg.GetCurrentBrainPath()
+	if err != nil {
+		return fmt.Errorf("failed to get brain path: %w", err)
+	}
+
+	result, err := api.RenameProject(brainPath, oldName, newName)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("OK: Renamed %s to %s\n", oldName, newName)
+
+	for _, path := range result.Updated {
+		if rel, err := filepath.Rel(brainPath, path); err == nil {
+			path = rel
+		}
+		fmt.Printf("  Updated references in %s\n", path)
+	}
+
+	if cfg.GetFocusedProject() == oldName {
+		if err := cfg.SetFocusedProject(newName); err != nil {
+			return fmt.Errorf("failed to update focus: %w", err)
+		}
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Printf("OK: Selected project: %s\n", newName)
+	}
+
+	if external.IsTmuxAvailable() {
+		oldSession, newSession := tmuxSessionName(oldName), tmuxSessionName(newName)
+		if external.HasSession(oldSession) {
+			if external.HasSession(newSession) {
+				fmt.Printf("Warning: tmux session %s already exists, %s not renamed\n", newSession, oldSession)
+			} else if err := external.RenameSession(oldSession, newSession); err != nil {
+				fmt.Printf("Warning: %v\n", err)
+			} else {
+				fmt.Printf("OK: Renamed tmux session to %s\n", newSession)
+			}
+		}
+	}
+
+	return nil
+}
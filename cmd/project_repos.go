@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/config"
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectReposJSONFlag bool
+	devRootLayoutFlag    string
+)
+
+var projectReposCmd = &cobra.Command{
+	Use:   "repos [name]",
+	Short: "List linked repositories and where they are checked out",
+	Long: `List the repositories in a project's .repos file with their local paths.
+
+Each .repos line is a git URL with optional path= and branch= options:
+
+  git@github.com:acme/api.git
+  git@github.com:acme/web.git path=~/work/acme-web branch=main
+
+Without path=, repositories are checked out under the dev root (see
+'brain project dev-root'). A relative path= is relative to the dev root.
+branch= is checked out when 'brain project pull' clones the repository.`,
+	Example: `  brain project repos
+  brain project repos website --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runProjectRepos,
+}
+
+var projectDevRootCmd = &cobra.Command{
+	Use:   "dev-root [path|default]",
+	Short: "Show or set where linked repositories are checked out",
+	Long: `Show or set the directory linked repositories are checked out in.
+
+Layouts:
+  flat   <root>/<name> (default)
+  host   <root>/<host>/<org>/<name>, e.g. ~/dev/github.com/acme/api
+
+The host layout keeps repositories with the same name from different
+organizations apart. The setting is stored per brain; 'default' restores
+~/dev with the flat layout. Existing checkouts are not moved.`,
+	Example: `  brain project dev-root
+  brain project dev-root ~/code --layout host
+  brain project dev-root --layout flat
+  brain project dev-root default`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runProjectDevRoot,
+}
+
+func init() {
+	projectCmd.AddCommand(projectReposCmd)
+	projectCmd.AddCommand(projectDevRootCmd)
+
+	projectReposCmd.Flags().BoolVar(&projectReposJSONFlag, "json", false, "Output JSON format")
+	projectDevRootCmd.Flags().StringVar(&devRootLayoutFlag, "layout", "", "Checkout layout: flat or host")
+}
+
+func runProjectRepos(cmd *cobra.Command, args []string) error {
+	projectDir, err := projectDirFromArgs(args)
+	if err != nil {
+		return err
+	}
+	projectName := filepath.Base(projectDir)
+
+	repos, err := loadLinkedRepos(projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to get linked repos: %w", err)
+	}
+
+	if projectReposJSONFlag {
+		data, err := json.MarshalIndent(repos, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(repos) == 0 {
+		fmt.Printf("No repositories linked to %s.\n", projectName)
+		return nil
+	}
+
+	fmt.Printf("Project: %s\n", projectName)
+	for _, repo := range repos {
+		status := "cloned"
+		if !fileutil.FileExists(repo.Path) {
+			status = "not cloned"
+		}
+		fmt.Printf("  %s\n", repo.URL)
+		fmt.Printf("    %s (%s)\n", repo.Path, status)
+		if repo.Branch != "" {
+			fmt.Printf("    branch: %s\n", repo.Branch)
+		}
+	}
+
+	return nil
+}
+
+func runProjectDevRoot(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	root, layout := cfg.GetRepoLocation()
+
+	if len(args) == 0 && devRootLayoutFlag == "" {
+		if root == "" {
+			root = api.DefaultDevRoot()
+		}
+		if layout == "" {
+			layout = api.RepoLayoutFlat
+		}
+		fmt.Printf("%s (layout: %s)\n", root, layout)
+		return nil
+	}
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "default":
+			root, layout = "", ""
+		default:
+			expanded, err := fileutil.ExpandPath(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to expand path: %w", err)
+			}
+			root, err = filepath.Abs(expanded)
+			if err != nil {
+				return fmt.Errorf("failed to resolve path: %w", err)
+			}
+		}
+	}
+	if devRootLayoutFlag != "" {
+		if !api.ValidRepoLayout(devRootLayoutFlag) {
+			return fmt.Errorf("invalid layout '%s' (must be %s or %s)", devRootLayoutFlag, api.RepoLayoutFlat, api.RepoLayoutHost)
+		}
+		layout = devRootLayoutFlag
+	}
+
+	if err := cfg.SetRepoLocation(root, layout); err != nil {
+		return err
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if root == "" {
+		root = api.DefaultDevRoot()
+	}
+	if layout == "" {
+		layout = api.RepoLayoutFlat
+	}
+	fmt.Printf("OK: Repositories are checked out in %s (layout: %s)\n", root, layout)
+	fmt.Println("Existing checkouts were not moved.")
+	return nil
+}
+
+// repoOptions returns the current brain's dev root and checkout layout
+func repoOptions() api.RepoOptions {
+	cfg, err := config.Load()
+	if err != nil {
+		return api.RepoOptions{}
+	}
+	root, layout := cfg.GetRepoLocation()
+	return api.RepoOptions{DevRoot: root, Layout: layout}
+}
+
+// loadLinkedRepos reads a project's .repos with the current brain's repo settings
+// Skipped lines are reported on stderr, so output meant for scripts stays clean.
+func loadLinkedRepos(projectDir string) ([]api.LinkedRepo, error) {
+	repos, warnings, err := api.LoadLinkedRepos(projectDir, repoOptions())
+	printRepoWarnings(projectDir, warnings)
+	return repos, err
+}
+
+// linkedRepoPaths returns the checkout paths of a project's linked repositories
+func linkedRepoPaths(projectDir string) ([]string, error) {
+	paths, warnings, err := api.GetLinkedRepos(projectDir, repoOptions())
+	printRepoWarnings(projectDir, warnings)
+	return paths, err
+}
+
+func printRepoWarnings(projectDir string, warnings []string) {
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s/%s\n", filepath.Base(projectDir), warning)
+	}
+}
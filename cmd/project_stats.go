@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectStatsJSONFlag  bool
+	projectStatsAllFlag   bool
+	projectStatsWeeksFlag int
+)
+
+var projectStatsCmd = &cobra.Command{
+	Use:   "stats [name]",
+	Short: "Show task statistics and burndown",
+	Long: `Show task statistics for a project (default: focused project).
+
+Includes task counts by status, overdue tasks, the average age of unfinished
+tasks, tasks added and completed per week, and a burnup/burndown chart.
+
+Weekly history uses the #captured: date tasks get when captured or refiled
+and the #done: date recorded when a task is marked done. Tasks without a
+#captured: date count as existing before the first week; done tasks without
+a #done: date are counted but left out of the weekly history.`,
+	Example: `  brain project stats
+  brain project stats backend-api --weeks 12
+  brain project stats --all --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runProjectStats,
+}
+
+func init() {
+	projectCmd.AddCommand(projectStatsCmd)
+
+	projectStatsCmd.Flags().BoolVar(&projectStatsJSONFlag, "json", false, "Output JSON format")
+	projectStatsCmd.Flags().BoolVar(&projectStatsAllFlag, "all", false, "Statistics across all active projects")
+	projectStatsCmd.Flags().IntVar(&projectStatsWeeksFlag, "weeks", api.DefaultStatsWeeks, "Weeks of history to show")
+}
+
+func runProjectStats(cmd *cobra.Command, args []string) error {
+	if projectStatsAllFlag && len(args) > 0 {
+		return fmt.Errorf("cannot combine a project name with --all")
+	}
+	if projectStatsWeeksFlag < 1 {
+		return fmt.Errorf("--weeks must be at least 1")
+	}
+
+	activeDir, err := getActiveDir()
+	if err != nil {
+		return err
+	}
+
+	projectName := ""
+	if !projectStatsAllFlag {
+		if len(args) > 0 {
+			projectName = args[0]
+		} else {
+			cfg, err := config.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			projectName = cfg.GetFocusedProject()
+			if projectName == "" {
+				return fmt.Errorf("no project specified and no focused project (use --all for all projects)")
+			}
+		}
+		if _, err := projectDirFromArgs([]string{projectName}); err != nil {
+			return err
+		}
+	}
+
+	todos, err := api.ParseAllTodos(activeDir, true)
+	if err != nil {
+		return fmt.Errorf("failed to parse todos: %w", err)
+	}
+
+	if projectName != "" {
+		var projectTodos []api.TodoItem
+		for _, todo := range todos {
+			if todo.Project == projectName {
+				projectTodos = append(projectTodos, todo)
+			}
+		}
+		todos = projectTodos
+	}
+
+	stats := api.ComputeStats(projectName, todos, time.Now(), projectStatsWeeksFlag)
+
+	if projectStatsJSONFlag {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	displayProjectStats(stats)
+	return nil
+}
+
+func displayProjectStats(stats api.ProjectStats) {
+	if stats.Project != "" {
+		fmt.Printf("Project: %s\n", stats.Project)
+	} else {
+		fmt.Println("All projects")
+	}
+	fmt.Println("")
+
+	fmt.Printf("Tasks:    %d open, %d in progress, %d blocked, %d done\n",
+		stats.Open, stats.InProgress, stats.Blocked, stats.Done)
+
+	fmt.Printf("Overdue:  %d\n", len(stats.Overdue))
+	for _, todo := range stats.Overdue {
+		line := fmt.Sprintf("  %s %s (due %s)", todo.ID, todo.Content, todo.DueDate)
+		if stats.Project == "" {
+			line += fmt.Sprintf(" (%s)", todo.Project)
+		}
+		fmt.Println(line)
+	}
+
+	if stats.Unfinished() > 0 {
+		dated := stats.Unfinished() - stats.UndatedOpen
+		if dated > 0 {
+			fmt.Printf("Age:      %.1f days on average for unfinished tasks", stats.AvgOpenAgeDays)
+		} else {
+			fmt.Print("Age:      unknown")
+		}
+		if stats.UndatedOpen > 0 {
+			fmt.Printf(" (%d without #captured: date)", stats.UndatedOpen)
+		}
+		fmt.Println("")
+	}
+
+	fmt.Println("")
+	fmt.Println("Week of     Added  Done  Remaining")
+	for _, week := range stats.Weeks {
+		fmt.Printf("%s  %5d %5d %10d\n", week.Start, week.Added, week.Completed, week.Remaining)
+	}
+
+	fmt.Println("")
+	fmt.Println("Burnup (█ done, ░ remaining)")
+	fmt.Print(api.BurnChart(stats.Weeks, 40))
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var projectTemplatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List project templates",
+	Long: `List the project templates in <brain>/templates/projects/.
+
+A project template is a directory whose files are copied into new projects,
+for example:
+
+  templates/projects/release/
+  ├── todo.md          # Seed tasks, e.g. a release checklist
+  ├── notes.md
+  ├── .repos           # Repositories to link
+  ├── .project.json    # Project metadata
+  └── notes/{{date}}-kickoff.md
+
+Variables in file names and text files are replaced:
+  {{project}}  Project name
+  {{date}}     Today (YYYY-MM-DD)
+  {{time}}     Current time (HH:MM)
+  {{weekday}}  Day of the week
+  {{year}}     Current year
+
+notes.md, todo.md and .repos fall back to the built-in defaults when the
+template does not provide them.`,
+	Args: cobra.NoArgs,
+	RunE: runProjectTemplates,
+}
+
+var projectTemplatesDefaultCmd = &cobra.Command{
+	Use:   "default [name|none]",
+	Short: "Show or set the default project template",
+	Long: `Show or set the template 'brain project new' uses without --template.
+
+Use 'none' to go back to the built-in project files.`,
+	Example: `  brain project templates default
+  brain project templates default standard
+  brain project templates default none`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runProjectTemplatesDefault,
+}
+
+func init() {
+	projectCmd.AddCommand(projectTemplatesCmd)
+	projectTemplatesCmd.AddCommand(projectTemplatesDefaultCmd)
+}
+
+func runProjectTemplates(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	brainPath, err := cfg.GetCurrentBrainPath()
+	if err != nil {
+		return fmt.Errorf("failed to get brain path: %w", err)
+	}
+
+	names, err := api.ListProjectTemplates(brainPath)
+	if err != nil {
+		return err
+	}
+
+	if len(names) == 0 {
+		fmt.Printf("No project templates in %s\n", api.ProjectTemplatesDir(brainPath))
+		return nil
+	}
+
+	defaultTemplate := cfg.GetDefaultProjectTemplate()
+	for _, name := range names {
+		marker := " "
+		if name == defaultTemplate {
+			marker = "*"
+		}
+		fmt.Printf(" %s %s\n", marker, name)
+	}
+
+	return nil
+}
+
+func runProjectTemplatesDefault(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if len(args) == 0 {
+		if name := cfg.GetDefaultProjectTemplate(); name != "" {
+			fmt.Println(name)
+		} else {
+			fmt.Println("(built-in)")
+		}
+		return nil
+	}
+
+	name := args[0]
+	if name == api.NoProjectTemplate {
+		name = ""
+	} else {
+		brainPath, err := cfg.GetCurrentBrainPath()
+		if err != nil {
+			return fmt.Errorf("failed to get brain path: %w", err)
+		}
+		names, err := api.ListProjectTemplates(brainPath)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(names, name) {
+			return fmt.Errorf("project template '%s' not found in %s", name, api.ProjectTemplatesDir(brainPath))
+		}
+	}
+
+	if err := cfg.SetDefaultProjectTemplate(name); err != nil {
+		return err
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if name == "" {
+		fmt.Println("OK: New projects use the built-in files")
+	} else {
+		fmt.Printf("OK: Default project template: %s\n", name)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var (
+	publishOutFlag        string
+	publishExcludeFlag    []string
+	publishPrivateTagFlag string
+)
+
+var publishCmd = &cobra.Command{
+	Use:   "publish [project...]",
+	Short: "Publish projects as a static HTML site",
+	Long: `Render projects to a static HTML site that can be opened in a browser or served as-is.
+
+The site contains an index page with task counts per project, one page per
+project (tasks grouped by status with priority badges and due dates, the
+project's notes.md and a list of notes), one page per note in notes/, and the
+attachments linked from published content. Wiki links between published notes
+become regular links.
+
+Without arguments all active projects are published. Tasks and notes
+(including notes.md) tagged with the private tag (default: #private) are left
+out; use --exclude to leave out whole projects. Pages and attachments the
+previous run wrote (listed in .brain-publish.json) that were not generated
+again are removed; other files in the output directory are never touched.`,
+	Example: `  brain publish --out ./site
+  brain publish backend-api --out /tmp/backend
+  brain publish --exclude personal --private-tag secret`,
+	RunE: runPublish,
+}
+
+func init() {
+	rootCmd.AddCommand(publishCmd)
+
+	publishCmd.Flags().StringVarP(&publishOutFlag, "out", "o", "site", "Output directory")
+	publishCmd.Flags().StringSliceVar(&publishExcludeFlag, "exclude", nil, "Projects to leave out (repeatable)")
+	publishCmd.Flags().StringVar(&publishPrivateTagFlag, "private-tag", api.DefaultPrivateTag, "Leave out tasks and notes with this tag (empty to publish everything)")
+}
+
+func runPublish(cmd *cobra.Command, args []string) error {
+	activeDir, err := getActiveDir()
+	if err != nil {
+		return err
+	}
+
+	result, err := api.Publish(activeDir, api.PublishOptions{
+		OutDir:     publishOutFlag,
+		Projects:   args,
+		Exclude:    publishExcludeFlag,
+		PrivateTag: publishPrivateTagFlag,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("OK: Published %d project(s) to %s (%d pages)\n", len(result.Projects), publishOutFlag, result.Pages)
+	if result.Removed > 0 {
+		fmt.Printf("  Removed %d stale file(s) from earlier runs\n", result.Removed)
+	}
+	return nil
+}
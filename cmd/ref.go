@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/external"
+	"github.com/spf13/cobra"
+)
+
+var todoOpenCmd = &cobra.Command{
+	Use:   "open [ID]",
+	Short: "Open the code a task refers to",
+	Long: `Open the file referenced by the task's #ref:path:line tag in the editor,
+positioned at the referenced line.
+
+References are resolved against the project's linked repositories. Tasks
+without a reference open at their own line in todo.md.
+
+If no ID is provided, shows interactive selection.`,
+	Example: `  brain todo open abc123  # Open referenced file
+  brain todo open         # Interactive selection`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTodoOpen,
+}
+
+var todoRefCmd = &cobra.Command{
+	Use:   "ref <ID> <file[:line]>",
+	Short: "Attach a code reference to a task",
+	Long: `Attach a file (and optional line) to a task as a #ref: tag.
+
+The file is resolved from the current directory and must be inside one of the
+project's linked repositories. It is stored relative to the repository:
+  #ref:backend-service/server.go:42
+
+Use 'clear' to remove the reference.`,
+	Example: `  brain todo ref abc123 server.go:42   # Reference line 42 of ./server.go
+  brain todo ref abc123 ./docs/api.md  # Reference a file
+  brain todo ref abc123 clear          # Remove reference`,
+	Args: cobra.ExactArgs(2),
+	RunE: runTodoRef,
+}
+
+func init() {
+	todoCmd.AddCommand(todoOpenCmd)
+	todoCmd.AddCommand(todoRefCmd)
+}
+
+func runTodoOpen(cmd *cobra.Command, args []string) error {
+	activeDir, err := getActiveDir()
+	if err != nil {
+		return err
+	}
+
+	var todo *api.TodoItem
+	if len(args) == 0 {
+		todo, err = selectTodo(activeDir, "open", "Select task to open")
+	} else {
+		todo, err = findTodo(activeDir, args[0], true)
+	}
+	if err != nil {
+		if err.Error() == "cancelled" {
+			return nil
+		}
+		return err
+	}
+
+	editor, err := external.DetectEditor()
+	if err != nil {
+		return err
+	}
+
+	if todo.Ref == "" {
+		return editor.OpenAtLine(todo.File, todo.Line)
+	}
+
+	repos, err := todoRepoPaths(activeDir, todo)
+	if err != nil {
+		return fmt.Errorf("failed to get linked repos: %w", err)
+	}
+
+	path, line, err := api.ResolveRef(todo.Ref, repos)
+	if err != nil {
+		fmt.Printf("Warning: %v, opening task instead\n", err)
+		return editor.OpenAtLine(todo.File, todo.Line)
+	}
+
+	if line == 0 {
+		return editor.Open(path)
+	}
+	return editor.OpenAtLine(path, line)
+}
+
+func runTodoRef(cmd *cobra.Command, args []string) error {
+	activeDir, err := getActiveDir()
+	if err != nil {
+		return err
+	}
+
+	todo, err := findTodo(activeDir, args[0], true)
+	if err != nil {
+		return err
+	}
+
+	if strings.ToLower(args[1]) == "clear" {
+		if err := api.SetTodoRef(todo, ""); err != nil {
+			return fmt.Errorf("failed to clear reference: %w", err)
+		}
+		fmt.Printf("OK: Cleared reference: %s\n", todo.Content)
+		return nil
+	}
+
+	filePath, line := api.ParseRef(args[1])
+
+	repos, err := todoRepoPaths(activeDir, todo)
+	if err != nil {
+		return fmt.Errorf("failed to get linked repos: %w", err)
+	}
+
+	ref, err := api.MakeRef(filePath, line, repos)
+	if err != nil {
+		return fmt.Errorf("%w of project '%s'", err, todo.Project)
+	}
+
+	if err := api.SetTodoRef(todo, ref); err != nil {
+		return fmt.Errorf("failed to set reference: %w", err)
+	}
+
+	fmt.Printf("OK: Set reference %s: %s\n", ref, todo.Content)
+	return nil
+}
+
+// todoRepoPaths returns the linked repositories of the task's project
+// Tasks from notes/*.md live below the project directory, so it is looked up by name.
+func todoRepoPaths(activeDir string, todo *api.TodoItem) ([]string, error) {
+	projectDir, err := api.FindProjectDir(activeDir, todo.Project)
+	if err != nil {
+		return nil, err
+	}
+	return linkedRepoPaths(projectDir)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -23,21 +24,56 @@ var refileCmd = &cobra.Command{
 	Long: `Process dump items to projects.
 
 Interactive mode (no arguments):
-  Process items one by one with fuzzy project selection
+  Decide for each item with fuzzy project selection. Decisions are
+  collected first and applied together when the session ends
+
+All modes apply their moves in one locked transaction against the dump,
+verifying each item's content before anything is removed. Use --dry-run
+to print the plan without changing anything.
 
 Direct mode (with ID and project):
-  Refile specific item to a specific project
+  Refile specific item to a specific project. Notes can be appended to
+  an existing note with <project>/<note-file>
+
+Automatic mode (--auto):
+  Apply the rules in <brain>/refile-rules and leave unmatched items
+  for interactive refiling. One rule per line, first match wins:
+    tag:infra        -> infra
+    keyword:invoice  -> admin
+    regex:^call\s    -> phone
+    tag:spam         -> [TRASH]
 
 Tasks go to project's todo.md
-Notes go to project's notes/ directory as separate files`,
+Notes go to project's notes/ directory as separate files, or are
+appended to notes.md or an existing note under a dated heading.
+With --import-files, local files the notes link to (absolute or ~/
+paths, up to 25 MB) are copied into the project's attachments/
+Links go to project's links.md
+Ideas go to project's someday.md
+Questions go to project's questions.md`,
 	Example: `  brain refile              # Start interactive refiling
-  brain refile a1b2c3 work  # Refile item a1b2c3 to 'work' project`,
+  brain refile a1b2c3 work  # Refile item a1b2c3 to 'work' project
+  brain refile d4e5f6 work/notes.md
+  brain refile --auto       # Apply refile-rules, leave the rest
+  brain refile --dry-run    # Decide interactively, only print the plan
+  brain refile --auto --dry-run
+  brain refile d4e5f6 work --import-files`,
 	Args: cobra.MaximumNArgs(2),
 	RunE: runRefile,
 }
 
+var (
+	refileAutoFlag        bool
+	refileDryRunFlag      bool
+	refileImportFilesFlag bool
+)
+
 func init() {
 	rootCmd.AddCommand(refileCmd)
+
+	refileCmd.Flags().BoolVar(&refileAutoFlag, "auto", false, "Refile items matching the brain's refile-rules")
+	refileCmd.Flags().BoolVar(&refileDryRunFlag, "dry-run", false, "Show what would be refiled without changing anything")
+	refileCmd.Flags().BoolVar(&refileImportFilesFlag, "import-files", false, "Copy local files linked from notes into the project's attachments")
 }
 
 func runRefile(cmd *cobra.Command, args []string) error {
@@ -62,18 +98,41 @@ func runRefile(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("active projects directory not found: %s", activeDir)
 	}
 
+	// Automatic mode: brain refile --auto [--dry-run]
+	if refileAutoFlag {
+		if len(args) > 0 {
+			return fmt.Errorf("--auto does not take arguments")
+		}
+		return refileAuto(filepath.Join(brainPath, "refile-rules"), dumpPath, activeDir, refileDryRunFlag)
+	}
+
 	// Direct mode: brain refile <ID> <project>
 	if len(args) == 2 {
 		itemID := args[0]
 		projectName := args[1]
-		return refileDirect(dumpPath, activeDir, itemID, projectName)
+		return refileDirect(dumpPath, activeDir, itemID, projectName, refileDryRunFlag)
 	}
 
 	// Interactive mode
-	return refileInteractive(dumpPath, activeDir)
+	return refileInteractive(dumpPath, activeDir, cfg.GetDumpMaxAge(api.DefaultDumpMaxAge), refileDryRunFlag)
 }
 
-func refileDirect(dumpPath, activeDir, itemID, projectName string) error {
+// refileDecision records where a dump item should go
+type refileDecision struct {
+	item     *markdown.DumpItem
+	target   string // Project name or api.RefileTargetTrash
+	noteFile string // Existing note to append to, relative to the project (notes only)
+}
+
+// destination describes where the decision sends the item
+func (d refileDecision) destination() string {
+	if d.noteFile != "" {
+		return d.target + "/" + d.noteFile
+	}
+	return d.target
+}
+
+func refileDirect(dumpPath, activeDir, itemID, projectName string, dryRun bool) error {
 	// Parse dump
 	items, err := markdown.ParseDumpFile(dumpPath)
 	if err != nil {
@@ -91,14 +150,7 @@ func refileDirect(dumpPath, activeDir, itemID, projectName string) error {
 	var targetItem *markdown.DumpItem
 	for i := range items {
 		item := &items[i]
-		var id string
-		if item.Type == markdown.ItemTypeTodo {
-			id = api.GenerateTaskID(item.StartLine, item.RawLine, mtime)
-		} else {
-			id = api.GenerateNoteID(item.StartLine, item.RawLine, mtime)
-		}
-
-		if id == itemID {
+		if api.DumpItemID(*item, mtime) == itemID {
 			targetItem = item
 			break
 		}
@@ -108,27 +160,116 @@ func refileDirect(dumpPath, activeDir, itemID, projectName string) error {
 		return fmt.Errorf("item with ID '%s' not found", itemID)
 	}
 
+	// Destination may name an existing note: <project>/<note-file>
+	projectName, noteName, _ := strings.Cut(projectName, "/")
+
 	// Verify project exists
-	projectDir := filepath.Join(activeDir, projectName)
-	if !fileutil.FileExists(projectDir) {
-		return fmt.Errorf("project '%s' not found", projectName)
+	projectDir, err := api.FindProjectDir(activeDir, projectName)
+	if err != nil {
+		return err
+	}
+
+	decision := refileDecision{item: targetItem, target: projectName}
+
+	if noteName != "" {
+		if targetItem.Type != markdown.ItemTypeNote {
+			return fmt.Errorf("only notes can be refiled into a note file")
+		}
+		noteFile, err := api.ResolveNoteTarget(projectDir, noteName)
+		if err != nil {
+			return err
+		}
+		decision.noteFile = noteFile
+	}
+
+	plan := []refileDecision{decision}
+
+	if dryRun {
+		printRefilePlan(plan, mtime)
+		return nil
+	}
+
+	if err := applyRefilePlan(dumpPath, activeDir, plan, mtime); err != nil {
+		return err
+	}
+
+	fmt.Printf("OK: Refiled item %s to %s\n", itemID, decision.destination())
+	return nil
+}
+
+func refileAuto(rulesPath, dumpPath, activeDir string, dryRun bool) error {
+	rules, err := api.LoadRefileRules(rulesPath)
+	if err != nil {
+		return fmt.Errorf("failed to load refile rules: %w", err)
+	}
+
+	if len(rules) == 0 {
+		return fmt.Errorf("no refile rules found. Add rules to %s", rulesPath)
+	}
+
+	projects, err := listProjects(activeDir)
+	if err != nil {
+		return err
+	}
+	projectSet := make(map[string]bool)
+	for _, project := range projects {
+		projectSet[project] = true
+	}
+
+	items, err := markdown.ParseDumpFile(dumpPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse dump: %w", err)
+	}
+
+	fileInfo, err := os.Stat(dumpPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat dump: %w", err)
+	}
+	mtime := fileInfo.ModTime().Unix()
+
+	// Decide destinations for all items before touching any file
+	var plan []refileDecision
+	unmatched := 0
+
+	for i := range items {
+		item := &items[i]
+
+		rule := api.MatchRefileRule(rules, *item)
+		if rule == nil {
+			unmatched++
+			continue
+		}
+
+		if rule.Target != api.RefileTargetTrash && !projectSet[rule.Target] {
+			content, _ := markdown.ExtractTimestamp(item.Content)
+			fmt.Printf("Warning: rule on line %d targets unknown project '%s', skipping: %s\n", rule.Line, rule.Target, content)
+			unmatched++
+			continue
+		}
+
+		plan = append(plan, refileDecision{item: item, target: rule.Target})
+	}
+
+	printRefilePlan(plan, mtime)
+
+	if dryRun {
+		fmt.Printf("\nDry run: %d items would be processed, %d left in dump\n", len(plan), unmatched)
+		return nil
 	}
 
-	// Refile item
-	if err := refileItem(targetItem, projectDir, dumpPath, mtime); err != nil {
+	if err := applyRefilePlan(dumpPath, activeDir, plan, mtime); err != nil {
 		return err
 	}
 
-	// Remove item from dump
-	if err := removeItemFromDump(dumpPath, targetItem.StartLine, targetItem.EndLine); err != nil {
-		return fmt.Errorf("failed to remove item from dump: %w", err)
+	fmt.Printf("\nProcessed %d items, %d left in dump\n", len(plan), unmatched)
+	if unmatched > 0 {
+		fmt.Println("Run 'brain refile' to process the rest interactively")
 	}
 
-	fmt.Printf("OK: Refiled item %s to %s\n", itemID, projectName)
 	return nil
 }
 
-func refileInteractive(dumpPath, activeDir string) error {
+func refileInteractive(dumpPath, activeDir string, maxAge int, dryRun bool) error {
 	// Check for fzf
 	if !external.IsFZFAvailable() {
 		return fmt.Errorf("fzf not found (required for interactive mode)")
@@ -144,8 +285,12 @@ func refileInteractive(dumpPath, activeDir string) error {
 		return fmt.Errorf("no projects found. Create one with: brain project new <name>")
 	}
 
-	// Add special options
-	options := append([]string{"[SKIP]", "[TRASH]"}, projects...)
+	// Similarity model for ranking destinations (cached in the user cache dir)
+	brainPath := filepath.Dir(dumpPath)
+	index := api.LoadSimilarityIndex(brainPath)
+	if err := index.Update(activeDir, projects); err != nil {
+		return fmt.Errorf("failed to update suggestions: %w", err)
+	}
 
 	// Parse dump
 	items, err := markdown.ParseDumpFile(dumpPath)
@@ -158,6 +303,10 @@ func refileInteractive(dumpPath, activeDir string) error {
 		return nil
 	}
 
+	if stale := sortStaleFirst(items, maxAge, time.Now()); stale > 0 {
+		fmt.Printf("%d item(s) older than %d days, showing the stalest first\n", stale, maxAge)
+	}
+
 	// Get file mtime
 	fileInfo, err := os.Stat(dumpPath)
 	if err != nil {
@@ -165,9 +314,10 @@ func refileInteractive(dumpPath, activeDir string) error {
 	}
 	mtime := fileInfo.ModTime().Unix()
 
-	processed := 0
+	// Collect decisions first; nothing is written until the session ends
+	var plan []refileDecision
 
-	// Process each item
+items:
 	for i := range items {
 		item := &items[i]
 
@@ -187,9 +337,11 @@ func refileInteractive(dumpPath, activeDir string) error {
 
 		fmt.Println(strings.Repeat("=", 60))
 
+		options, header := rankDestinations(index, item, dumpPath, projects)
+
 		// Select project
 		selected, err := external.SelectOne(options, external.FZFOptions{
-			Header: "Select destination project",
+			Header: header,
 			Prompt: "Project> ",
 			Height: "40%",
 		})
@@ -202,49 +354,279 @@ func refileInteractive(dumpPath, activeDir string) error {
 			return err
 		}
 
+		// Notes can go into a new file, notes.md or an existing note
+		noteFile := ""
+		if item.Type == markdown.ItemTypeNote && selected != "[SKIP]" && selected != "[TRASH]" {
+			projectDir, err := api.FindProjectDir(activeDir, selected)
+			if err != nil {
+				return err
+			}
+			noteFile, err = selectNoteTarget(projectDir)
+			if err != nil {
+				if err.Error() == "cancelled" {
+					fmt.Println("\nRefile cancelled")
+					break items
+				}
+				return err
+			}
+		}
+
 		// Handle selection
-		if selected == "[SKIP]" {
+		switch selected {
+		case "[SKIP]":
 			fmt.Println("Skipped")
-			continue
+		case "[TRASH]":
+			plan = append(plan, refileDecision{item: item, target: api.RefileTargetTrash})
+			fmt.Println("Marked for deletion")
+		default:
+			decision := refileDecision{item: item, target: selected, noteFile: noteFile}
+			plan = append(plan, decision)
+			fmt.Printf("Planned: %s\n", decision.destination())
 		}
+	}
 
-		if selected == "[TRASH]" {
-			// Remove from dump
-			if err := removeItemFromDump(dumpPath, item.StartLine, item.EndLine); err != nil {
-				return fmt.Errorf("failed to remove item: %w", err)
+	if len(plan) == 0 {
+		fmt.Println("\nNothing to refile")
+		return nil
+	}
+
+	fmt.Println("")
+	printRefilePlan(plan, mtime)
+
+	if dryRun {
+		fmt.Printf("\nDry run: %d items would be processed\n", len(plan))
+		return nil
+	}
+
+	if err := applyRefilePlan(dumpPath, activeDir, plan, mtime); err != nil {
+		return err
+	}
+
+	// Cache the index for the next session (non-critical, ignore errors)
+	if err := index.Update(activeDir, projects); err == nil {
+		_ = index.Save()
+	}
+
+	fmt.Printf("\nProcessed %d items\n", len(plan))
+	return nil
+}
+
+// sortStaleFirst orders items oldest first when any item is older than maxAge days
+// Returns the number of stale items; the order is unchanged when there are none
+func sortStaleFirst(items []markdown.DumpItem, maxAge int, now time.Time) int {
+	if maxAge == 0 {
+		return 0
+	}
+
+	ages := make(map[int]int) // StartLine -> age in days, dated items only
+	stale := 0
+	for _, item := range items {
+		_, timestamp := markdown.ExtractTimestamp(item.Content)
+		if age, ok := api.CapturedAge(timestamp, now); ok {
+			ages[item.StartLine] = age
+			if age > maxAge {
+				stale++
 			}
-			fmt.Println("Deleted")
-			processed++
-			continue
 		}
+	}
 
-		// Refile to project
-		projectDir := filepath.Join(activeDir, selected)
-		if err := refileItem(item, projectDir, dumpPath, mtime); err != nil {
-			return err
+	if stale == 0 {
+		return 0
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		ageI, okI := ages[items[i].StartLine]
+		ageJ, okJ := ages[items[j].StartLine]
+		if okI != okJ {
+			return okI
+		}
+		return ageI > ageJ
+	})
+
+	return stale
+}
+
+// printRefilePlan shows where each planned item will go
+func printRefilePlan(plan []refileDecision, mtime int64) {
+	for _, decision := range plan {
+		content, _ := markdown.ExtractTimestamp(decision.item.Content)
+		id := api.DumpItemID(*decision.item, mtime)
+		fmt.Printf("  %s -> %s: %s\n", id, decision.destination(), content)
+	}
+}
+
+// applyRefilePlan applies all decisions as one transaction under the dump lock
+// Every item is verified against the dump before anything is written, then items
+// are moved to their projects and finally removed from the dump in a single write.
+// If a move fails, only the items that were already moved are removed from the dump.
+func applyRefilePlan(dumpPath, activeDir string, plan []refileDecision, mtime int64) error {
+	if len(plan) == 0 {
+		return nil
+	}
+
+	return fileutil.WithLock(dumpPath, func() error {
+		items := make([]markdown.DumpItem, 0, len(plan))
+		for _, decision := range plan {
+			items = append(items, *decision.item)
 		}
 
-		// Remove from dump
-		if err := removeItemFromDump(dumpPath, item.StartLine, item.EndLine); err != nil {
-			return fmt.Errorf("failed to remove item: %w", err)
+		if err := api.VerifyDumpItems(dumpPath, items); err != nil {
+			return fmt.Errorf("refile aborted, nothing was changed: %w", err)
 		}
 
-		fmt.Printf("Refiled to %s\n", selected)
-		processed++
+		// Resolve every target first so an unknown project stops the refile before anything moves
+		projectDirs := make(map[string]string)
+		for _, decision := range plan {
+			if decision.target == api.RefileTargetTrash {
+				continue
+			}
+			if _, ok := projectDirs[decision.target]; ok {
+				continue
+			}
+			projectDir, err := api.FindProjectDir(activeDir, decision.target)
+			if err != nil {
+				return fmt.Errorf("refile aborted, nothing was changed: %w", err)
+			}
+			projectDirs[decision.target] = projectDir
+		}
+
+		var done []markdown.DumpItem
+		for _, decision := range plan {
+			if decision.target != api.RefileTargetTrash {
+				if err := refileItem(decision.item, projectDirs[decision.target], decision.noteFile, dumpPath, mtime); err != nil {
+					if rmErr := api.RemoveDumpItems(dumpPath, done); rmErr != nil {
+						return fmt.Errorf("%w (and failed to clean up dump: %v)", err, rmErr)
+					}
+					return fmt.Errorf("refile stopped after %d of %d items: %w", len(done), len(plan), err)
+				}
+			}
+			done = append(done, *decision.item)
+		}
+
+		if err := api.RemoveDumpItems(dumpPath, done); err != nil {
+			return fmt.Errorf("failed to remove items from dump: %w", err)
+		}
+		return nil
+	})
+}
+
+// listDestination describes the project file a single-line dump item type is refiled into
+type listDestination struct {
+	file   string
+	header string
+}
+
+// listDestinations maps single-line dump item types to their project file
+var listDestinations = map[markdown.ItemType]listDestination{
+	markdown.ItemTypeLink:     {file: "links.md", header: "# Links"},
+	markdown.ItemTypeIdea:     {file: "someday.md", header: "# Someday"},
+	markdown.ItemTypeQuestion: {file: "questions.md", header: "# Questions"},
+}
+
+func refileItem(item *markdown.DumpItem, projectDir, noteFile, dumpPath string, mtime int64) error {
+	var err error
+	switch item.Type {
+	case markdown.ItemTypeTodo:
+		err = refileTask(item, projectDir)
+	case markdown.ItemTypeNote:
+		if noteFile != "" {
+			err = refileNoteInto(item, projectDir, filepath.Join(projectDir, noteFile), dumpPath)
+		} else {
+			err = refileNote(item, projectDir, dumpPath)
+		}
+	default:
+		dest, ok := listDestinations[item.Type]
+		if !ok {
+			return fmt.Errorf("cannot refile item of type '%s'", item.Type)
+		}
+		err = refileListItem(item, projectDir, dest)
 	}
 
-	fmt.Printf("\nProcessed %d items\n", processed)
+	if err != nil {
+		return err
+	}
+
+	// Remember the decision for destination suggestions (non-critical, ignore errors)
+	content, _ := markdown.ExtractTimestamp(item.Content)
+	_ = api.AppendRefileHistory(filepath.Dir(dumpPath), filepath.Base(projectDir), content)
 	return nil
 }
 
-func refileItem(item *markdown.DumpItem, projectDir, dumpPath string, mtime int64) error {
-	if item.Type == markdown.ItemTypeTodo {
-		return refileTask(item, projectDir)
+// rankDestinations orders refile options by similarity to the item
+// The most likely project comes first so it is under the fzf cursor; without any
+// signal the original order ([SKIP], [TRASH], then alphabetical) is kept
+func rankDestinations(idx *api.SimilarityIndex, item *markdown.DumpItem, dumpPath string, projects []string) ([]string, string) {
+	header := "Select destination project"
+	defaults := append([]string{"[SKIP]", "[TRASH]"}, projects...)
+
+	text, _ := markdown.ExtractTimestamp(item.Content)
+	if item.Type == markdown.ItemTypeNote {
+		if body, err := readNoteContent(dumpPath, item.StartLine, item.EndLine); err == nil {
+			text += "\n" + body
+		}
+	}
+
+	ranked := idx.Rank(text)
+	if len(ranked) == 0 || ranked[0].Score == 0 {
+		return defaults, header
+	}
+
+	var options, rest []string
+	for _, suggestion := range ranked {
+		if suggestion.Score > 0 {
+			options = append(options, suggestion.Project)
+		} else {
+			rest = append(rest, suggestion.Project)
+		}
 	}
-	return refileNote(item, projectDir, dumpPath)
+	sort.Strings(rest)
+	options = append(options, "[SKIP]", "[TRASH]")
+	options = append(options, rest...)
+
+	header = fmt.Sprintf("%s (suggested: %s, %.0f%% match)", header, ranked[0].Project, ranked[0].Score*100)
+	return options, header
+}
+
+// refileListItem appends a single-line item as a bullet to a project list file
+// Links become markdown links; everything else keeps its text and captured timestamp
+func refileListItem(item *markdown.DumpItem, projectDir string, dest listDestination) error {
+	listFile := filepath.Join(projectDir, dest.file)
+
+	// Ensure list file exists
+	if !fileutil.FileExists(listFile) {
+		if err := os.WriteFile(listFile, []byte(dest.header+"\n\n"), 0644); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dest.file, err)
+		}
+	}
+
+	entry := item.Content
+	if item.Type == markdown.ItemTypeLink {
+		content, timestamp := markdown.ExtractTimestamp(item.Content)
+		url, title := markdown.ParseLink(content)
+		entry = fmt.Sprintf("[%s](%s)", title, url)
+		if timestamp != "" {
+			entry += " #captured:" + timestamp
+		}
+	}
+
+	return fileutil.WithLock(listFile, func() error {
+		f, err := os.OpenFile(listFile, os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = fmt.Fprintf(f, "- %s\n", entry)
+		return err
+	})
 }
 
 func refileTask(item *markdown.DumpItem, projectDir string) error {
+	return appendTaskToProject(projectDir, item.Content)
+}
+
+// appendTaskToProject appends an open task to a project's todo.md, creating it if needed
+func appendTaskToProject(projectDir, content string) error {
 	todoFile := filepath.Join(projectDir, "todo.md")
 
 	// Ensure todo.md exists
@@ -268,7 +650,7 @@ func refileTask(item *markdown.DumpItem, projectDir string) error {
 		}
 		defer f.Close()
 
-		_, err = fmt.Fprintf(f, "- [ ] %s\n", item.Content)
+		_, err = fmt.Fprintf(f, "- [ ] %s\n", content)
 		return err
 	})
 
@@ -281,35 +663,21 @@ func refileNote(item *markdown.DumpItem, projectDir, dumpPath string) error {
 		return fmt.Errorf("failed to create notes directory: %w", err)
 	}
 
-	// Extract date and clean title
-	capturedDate := time.Now().Format("2006-01-02")
-	cleanTitle := item.Content
+	cleanTitle, capturedDate := noteTitleAndDate(item)
 
-	timestampPattern := regexp.MustCompile(`\s*#captured:([0-9-]+)`)
-	if matches := timestampPattern.FindStringSubmatch(item.Content); matches != nil {
-		capturedDate = matches[1]
-		cleanTitle = timestampPattern.ReplaceAllString(item.Content, "")
+	// #tags in the title become front matter tags
+	title, tags := markdown.ExtractTags(cleanTitle)
+	if title == "" {
+		title = cleanTitle
 	}
 
-	cleanTitle = strings.TrimSpace(cleanTitle)
-
 	// Create slug
-	slug := slugify(cleanTitle)
+	slug := slugify(title)
 	if slug == "" {
 		slug = "note"
 	}
 
-	// Create filename
-	filename := fmt.Sprintf("%s-%s.md", capturedDate, slug)
-	filePath := filepath.Join(notesDir, filename)
-
-	// Handle duplicates
-	counter := 1
-	for fileutil.FileExists(filePath) {
-		filename = fmt.Sprintf("%s-%s-%d.md", capturedDate, slug, counter)
-		filePath = filepath.Join(notesDir, filename)
-		counter++
-	}
+	filePath := newNotePath(notesDir, capturedDate, slug)
 
 	// Get note content from dump
 	content, err := readNoteContent(dumpPath, item.StartLine, item.EndLine)
@@ -317,8 +685,16 @@ func refileNote(item *markdown.DumpItem, projectDir, dumpPath string) error {
 		return fmt.Errorf("failed to read note content: %w", err)
 	}
 
+	// Copy linked local files into the project's attachments
+	if refileImportFilesFlag {
+		content, _, err = api.ImportLinkedFiles(projectDir, filePath, content)
+		if err != nil {
+			return fmt.Errorf("failed to attach linked files: %w", err)
+		}
+	}
+
 	// Create note file
-	noteContent := fmt.Sprintf("# %s\n\nCreated: %s\n\n%s\n", cleanTitle, capturedDate, content)
+	noteContent := api.NewNoteContent(title, capturedDate, tags, content)
 	if err := os.WriteFile(filePath, []byte(noteContent), 0644); err != nil {
 		return fmt.Errorf("failed to create note file: %w", err)
 	}
@@ -326,6 +702,81 @@ func refileNote(item *markdown.DumpItem, projectDir, dumpPath string) error {
 	return nil
 }
 
+// refileNoteInto appends a dump note to an existing note under a dated heading
+func refileNoteInto(item *markdown.DumpItem, projectDir, notePath, dumpPath string) error {
+	cleanTitle, capturedDate := noteTitleAndDate(item)
+
+	content, err := readNoteContent(dumpPath, item.StartLine, item.EndLine)
+	if err != nil {
+		return fmt.Errorf("failed to read note content: %w", err)
+	}
+
+	// Copy linked local files into the project's attachments
+	if refileImportFilesFlag {
+		content, _, err = api.ImportLinkedFiles(projectDir, notePath, content)
+		if err != nil {
+			return fmt.Errorf("failed to attach linked files: %w", err)
+		}
+	}
+
+	if err := api.AppendNoteSection(notePath, capturedDate, cleanTitle, content); err != nil {
+		return fmt.Errorf("failed to append to note: %w", err)
+	}
+
+	return nil
+}
+
+// noteTitleAndDate splits a note title from its #captured: date (today if missing)
+func noteTitleAndDate(item *markdown.DumpItem) (string, string) {
+	capturedDate := time.Now().Format("2006-01-02")
+	cleanTitle := item.Content
+
+	timestampPattern := regexp.MustCompile(`\s*#captured:([0-9-]+)`)
+	if matches := timestampPattern.FindStringSubmatch(item.Content); matches != nil {
+		capturedDate = matches[1]
+		cleanTitle = timestampPattern.ReplaceAllString(item.Content, "")
+	}
+
+	return strings.TrimSpace(cleanTitle), capturedDate
+}
+
+// selectNoteTarget asks where a note should go inside a project
+// Returns "" for a new file, otherwise the chosen note relative to the project
+func selectNoteTarget(projectDir string) (string, error) {
+	const newFile = "[NEW FILE]"
+	options := []string{newFile}
+
+	if fileutil.FileExists(filepath.Join(projectDir, "notes.md")) {
+		options = append(options, "notes.md")
+	}
+
+	notes, err := api.ListNotes(projectDir)
+	if err != nil {
+		return "", err
+	}
+	for _, note := range notes {
+		options = append(options, filepath.Join("notes", note.Filename))
+	}
+
+	if len(options) == 1 {
+		return "", nil
+	}
+
+	selected, err := external.SelectOne(options, external.FZFOptions{
+		Header: "Select note to append to",
+		Prompt: "Note> ",
+		Height: "40%",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if selected == newFile {
+		return "", nil
+	}
+	return selected, nil
+}
+
 func readNoteContent(dumpPath string, startLine, endLine int) (string, error) {
 	file, err := os.Open(dumpPath)
 	if err != nil {
@@ -366,43 +817,23 @@ func readNoteContent(dumpPath string, startLine, endLine int) (string, error) {
 	return strings.Join(contentLines, "\n"), scanner.Err()
 }
 
-func removeItemFromDump(dumpPath string, startLine, endLine int) error {
-	// Read entire file
-	content, err := os.ReadFile(dumpPath)
-	if err != nil {
-		return err
-	}
-
-	lines := strings.Split(string(content), "\n")
-
-	// Remove lines (1-indexed to 0-indexed conversion)
-	var newLines []string
-	for i, line := range lines {
-		lineNum := i + 1
-		if lineNum < startLine || lineNum > endLine {
-			newLines = append(newLines, line)
-		}
-	}
-
-	// Write back
-	newContent := strings.Join(newLines, "\n")
-	return fileutil.AtomicWriteFile(dumpPath, []byte(newContent))
+// listProjects returns the names of projects in every container
+func listProjects(activeDir string) ([]string, error) {
+	return api.ProjectNames(activeDir)
 }
 
-func listProjects(activeDir string) ([]string, error) {
-	entries, err := os.ReadDir(activeDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read active directory: %w", err)
-	}
+// newNotePath returns notes/<date>-<slug>.md, adding a counter if the file exists
+func newNotePath(notesDir, date, slug string) string {
+	filePath := filepath.Join(notesDir, fmt.Sprintf("%s-%s.md", date, slug))
 
-	var projects []string
-	for _, entry := range entries {
-		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
-			projects = append(projects, entry.Name())
-		}
+	// Handle duplicates
+	counter := 1
+	for fileutil.FileExists(filePath) {
+		filePath = filepath.Join(notesDir, fmt.Sprintf("%s-%s-%d.md", date, slug, counter))
+		counter++
 	}
 
-	return projects, nil
+	return filePath
 }
 
 func slugify(text string) string {
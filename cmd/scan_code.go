@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/config"
+	"github.com/sandermoonemans/local-brain/pkg/external"
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+	"github.com/spf13/cobra"
+)
+
+var scanCodeDryRunFlag bool
+
+var scanCodeCmd = &cobra.Command{
+	Use:   "scan-code [project]",
+	Short: "Harvest TODO/FIXME/HACK comments from linked repos",
+	Long: `Scan the project's linked repositories for TODO, FIXME and HACK comments
+and add them as tasks to the project's todo.md.
+
+Each task gets a back-reference to its source:
+  - [ ] handle timeout #todo #ref:api/server.go:42
+
+Re-scanning is safe:
+  - Comments that already have a task are not added again
+  - Line numbers are updated when code moves
+  - Open tasks whose comment disappeared are tagged #ref-gone
+    (the tag is removed again if the comment comes back)
+
+Files ignored by .gitignore are skipped. Without a project argument the
+project is resolved from the current directory, the focused project, or
+interactive selection.`,
+	Example: `  brain scan-code              # Scan repos of the current project
+  brain scan-code backend-api  # Scan repos of a specific project
+  brain scan-code --dry-run    # Show proposed tasks without writing`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runScanCode,
+}
+
+func init() {
+	rootCmd.AddCommand(scanCodeCmd)
+
+	scanCodeCmd.Flags().BoolVar(&scanCodeDryRunFlag, "dry-run", false, "Show proposed changes without writing todo.md")
+}
+
+func runScanCode(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var projectName, projectDir string
+	if len(args) > 0 {
+		brainPath, err := cfg.GetCurrentBrainPath()
+		if err != nil {
+			return fmt.Errorf("failed to get brain path: %w", err)
+		}
+		projectName = args[0]
+		projectDir, err = api.FindProjectDir(filepath.Join(brainPath, api.ActiveDirName), projectName)
+		if err != nil {
+			return err
+		}
+	} else {
+		projectName, projectDir, err = resolveTargetProject(cfg, "scan for code comments")
+		if err != nil {
+			return err
+		}
+	}
+
+	repos, err := linkedRepoPaths(projectDir)
+	if err != nil {
+		return fmt.Errorf("failed to get linked repos: %w", err)
+	}
+
+	if len(repos) == 0 {
+		fmt.Println("No repositories linked.")
+		return nil
+	}
+
+	fmt.Printf("Project: %s\n", projectName)
+
+	var comments []api.CodeComment
+	var scanned []string
+
+	for _, repoPath := range repos {
+		repoName := filepath.Base(repoPath)
+
+		if !fileutil.FileExists(repoPath) {
+			fmt.Printf("  %s: not cloned, skipping (run 'brain project pull')\n", repoName)
+			continue
+		}
+
+		files, err := listRepoFiles(repoPath)
+		if err != nil {
+			fmt.Printf("  %s: ERROR: %v\n", repoName, err)
+			continue
+		}
+
+		repoComments, err := api.ScanRepoComments(repoPath, files)
+		if err != nil {
+			fmt.Printf("  %s: ERROR: %v\n", repoName, err)
+			continue
+		}
+
+		fmt.Printf("  %s: %d comment(s) in %d file(s)\n", repoName, len(repoComments), len(files))
+		comments = append(comments, repoComments...)
+		scanned = append(scanned, repoName)
+	}
+
+	if len(scanned) == 0 {
+		return nil
+	}
+
+	todoPath := filepath.Join(projectDir, "todo.md")
+	result, err := api.SyncCodeTasks(todoPath, comments, scanned, scanCodeDryRunFlag)
+	if err != nil {
+		return fmt.Errorf("failed to update todo.md: %w", err)
+	}
+
+	fmt.Println("")
+	for _, comment := range result.New {
+		fmt.Printf("  + %s (%s)\n", comment.Text, comment.Ref())
+	}
+	for _, text := range result.Gone {
+		fmt.Printf("  ! %s (comment removed)\n", text)
+	}
+
+	summary := fmt.Sprintf("%d new, %d moved, %d gone, %d restored", len(result.New), result.Moved, len(result.Gone), result.Revived)
+	if scanCodeDryRunFlag {
+		fmt.Printf("\nDry run: %s\n", summary)
+		return nil
+	}
+
+	fmt.Printf("\nOK: %s\n", summary)
+	return nil
+}
+
+// listRepoFiles lists files to scan, respecting .gitignore when the repo uses git
+// Non-git directories are walked directly, skipping hidden directories
+func listRepoFiles(repoPath string) ([]string, error) {
+	if external.IsGitRepo(repoPath) {
+		return external.ListFiles(repoPath)
+	}
+
+	var files []string
+	err := filepath.WalkDir(repoPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != repoPath && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(repoPath, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+
+	return files, err
+}
@@ -338,7 +338,7 @@ func selectTodoByStatus(activeDir string, statuses []string, prompt string) (*ap
 		}
 	}
 
-	todos, err := api.ParseAllTodos(activeDir, includeCompleted)
+	todos, err := loadTodos(activeDir, includeCompleted)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse todos: %w", err)
 	}
@@ -58,7 +58,7 @@ func runTags(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	todos, err := api.ParseAllTodos(activeDir, false)
+	todos, err := loadTodos(activeDir, false)
 	if err != nil {
 		return fmt.Errorf("failed to parse todos: %w", err)
 	}
@@ -141,7 +141,7 @@ func runTagInteractive(activeDir string) error {
 		}
 
 		// Get all existing tags for suggestions
-		todos, _ := api.ParseAllTodos(activeDir, false)
+		todos, _ := loadTodos(activeDir, false)
 		allTags := api.ListAllTags(todos)
 		var suggestions []string
 		for tag := range allTags {
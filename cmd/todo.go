@@ -42,11 +42,15 @@ Subcommands:
   ls          List tasks
   done        Mark task as complete
   delete      Delete a task
-  reopen      Reopen a completed task`,
+  reopen      Reopen a completed task
+  open        Open the code a task refers to (#ref:path:line)
+  ref         Attach a code reference to a task
+  notes       Include checkboxes from notes in task lists`,
 	Example: `  brain todo                  # Browse and select from all open tasks
   brain todo ls               # List all open tasks
   brain todo ls --json        # List as JSON with IDs
   brain todo ls --all         # Include completed tasks
+  brain todo ls --include-notes  # Include checkboxes from notes
   brain todo done abc123      # Mark complete by ID
   brain todo delete abc123    # Delete by ID with confirmation
   brain todo reopen abc123    # Reopen a completed task`,
@@ -417,6 +421,20 @@ func displayTodos(todos []api.TodoItem) {
 		// Add project
 		line += fmt.Sprintf(" (%s)", todo.Project)
 
+		if todo.Archived {
+			line += " [Archived]"
+		}
+
+		// Add note the task lives in
+		if todo.FromNote() {
+			line += fmt.Sprintf(" [Note: %s]", todo.Source)
+		}
+
+		// Add code reference
+		if todo.Ref != "" {
+			line += fmt.Sprintf(" [Ref: %s]", todo.Ref)
+		}
+
 		// Add due date with overdue highlighting
 		if todo.DueDate != "" {
 			dueDate, err := time.Parse("2006-01-02", todo.DueDate)
@@ -448,11 +466,19 @@ func runTodoLs(cmd *cobra.Command, args []string) error {
 
 	activeDir := filepath.Join(brainPath, "01_active")
 
-	todos, err := api.ParseAllTodos(activeDir, todoAllFlag)
+	todos, err := loadTodos(activeDir, todoAllFlag)
 	if err != nil {
 		return fmt.Errorf("failed to parse todos: %w", err)
 	}
 
+	if todoArchivedFlag {
+		archived, err := api.ParseArchivedTodos(brainPath, todoAllFlag)
+		if err != nil {
+			return fmt.Errorf("failed to parse archived todos: %w", err)
+		}
+		todos = append(todos, archived...)
+	}
+
 	// Apply filters
 	todos = filterTodos(todos)
 
@@ -617,7 +643,7 @@ func runTodoInteractive(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get all open tasks
-	todos, err := api.ParseAllTodos(activeDir, false)
+	todos, err := loadTodos(activeDir, false)
 	if err != nil {
 		return fmt.Errorf("failed to parse todos: %w", err)
 	}
@@ -687,7 +713,8 @@ func getActiveDir() (string, error) {
 }
 
 func findTodo(activeDir, query string, includeCompleted bool) (*api.TodoItem, error) {
-	todos, err := api.ParseAllTodos(activeDir, includeCompleted)
+	// Lookups always include notes so every task can be edited by ID
+	todos, err := api.ParseAllTodosWithNotes(activeDir, includeCompleted)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse todos: %w", err)
 	}
@@ -715,7 +742,7 @@ func findTodo(activeDir, query string, includeCompleted bool) (*api.TodoItem, er
 func selectTodo(activeDir, filter, prompt string) (*api.TodoItem, error) {
 	includeCompleted := filter == "all" || filter == "done"
 
-	todos, err := api.ParseAllTodos(activeDir, includeCompleted)
+	todos, err := loadTodos(activeDir, includeCompleted)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse todos: %w", err)
 	}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/api"
+	"github.com/sandermoonemans/local-brain/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var todoIncludeNotesFlag bool
+
+var todoNotesCmd = &cobra.Command{
+	Use:   "notes [on|off]",
+	Short: "Show or set whether task lists include checkboxes from notes",
+	Long: `Show or set whether task lists include checkboxes written in notes.
+
+Action items in notes.md and notes/*.md (e.g. "- [ ] Send slides" in meeting
+notes) are tasks too. When enabled, 'brain todo ls', 'brain plan', the
+interactive pickers and tag listings show them next to todo.md tasks.
+Use 'brain todo ls --include-notes' for a one-off listing instead.
+
+Commands that take a task ID (done, reopen, prio, due, tag, ...) always find
+tasks in notes, and edit them in place in the note.`,
+	Example: `  brain todo notes       # Show current setting
+  brain todo notes on    # Include note checkboxes in task lists
+  brain todo notes off`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"on", "off"},
+	RunE:      runTodoNotes,
+}
+
+func init() {
+	todoCmd.AddCommand(todoNotesCmd)
+
+	todoLsCmd.Flags().BoolVar(&todoIncludeNotesFlag, "include-notes", false, "Include checkboxes from notes.md and notes/*.md")
+}
+
+func runTodoNotes(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if len(args) == 0 {
+		if cfg.GetTodoIncludeNotes() {
+			fmt.Println("on (task lists include checkboxes from notes)")
+		} else {
+			fmt.Println("off (task lists show todo.md only)")
+		}
+		return nil
+	}
+
+	var include bool
+	switch strings.ToLower(args[0]) {
+	case "on", "true", "yes":
+		include = true
+	case "off", "false", "no":
+		include = false
+	default:
+		return fmt.Errorf("invalid value '%s' (must be on or off)", args[0])
+	}
+
+	if err := cfg.SetTodoIncludeNotes(include); err != nil {
+		return err
+	}
+
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if include {
+		fmt.Println("OK: Task lists include checkboxes from notes")
+	} else {
+		fmt.Println("OK: Task lists show todo.md only")
+	}
+	return nil
+}
+
+// loadTodos parses tasks for listings and pickers
+// Checkboxes from notes are included with --include-notes or the brain's setting
+func loadTodos(activeDir string, includeCompleted bool) ([]api.TodoItem, error) {
+	includeNotes := todoIncludeNotesFlag
+	if !includeNotes {
+		if cfg, err := config.Load(); err == nil {
+			includeNotes = cfg.GetTodoIncludeNotes()
+		}
+	}
+
+	if includeNotes {
+		return api.ParseAllTodosWithNotes(activeDir, includeCompleted)
+	}
+	return api.ParseAllTodos(activeDir, includeCompleted)
+}
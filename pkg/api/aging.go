@@ -0,0 +1,109 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/dateutil"
+)
+
+// DefaultDumpMaxAge is the number of days after which dump items count as stale
+const DefaultDumpMaxAge = 7
+
+// AgeBucket counts dump items within an age range (in days, inclusive)
+type AgeBucket struct {
+	Label   string `json:"label"`
+	MinDays int    `json:"min_days"`
+	MaxDays int    `json:"max_days"` // -1 means no upper bound
+	Count   int    `json:"count"`
+}
+
+// DumpStats summarizes how long items have been waiting in the dump
+type DumpStats struct {
+	Count     int           `json:"count"`
+	Undated   int           `json:"undated"` // Items without a #captured: date
+	Stale     int           `json:"stale"`   // Items older than the threshold
+	MaxAge    int           `json:"max_age"` // Threshold in days (0 = disabled)
+	OldestAge int           `json:"oldest_age"`
+	Oldest    *DumpItemJSON `json:"oldest,omitempty"`
+	Histogram []AgeBucket   `json:"histogram"`
+}
+
+// CapturedAge returns how many days ago an item with this #captured: date was captured
+// The second return value is false if the date is missing or invalid
+func CapturedAge(timestamp string, now time.Time) (int, bool) {
+	if timestamp == "" {
+		return 0, false
+	}
+	days, err := dateutil.DaysSince(timestamp, now)
+	if err != nil {
+		return 0, false
+	}
+	return days, true
+}
+
+// ComputeDumpStats builds the age report for dump items
+// Items older than maxAge days are counted as stale (maxAge 0 disables this)
+func ComputeDumpStats(items []DumpItemJSON, maxAge int, now time.Time) DumpStats {
+	stats := DumpStats{
+		Count:  len(items),
+		MaxAge: maxAge,
+		Histogram: []AgeBucket{
+			{Label: "0-1d", MinDays: 0, MaxDays: 1},
+			{Label: "2-7d", MinDays: 2, MaxDays: 7},
+			{Label: "8-14d", MinDays: 8, MaxDays: 14},
+			{Label: "15-30d", MinDays: 15, MaxDays: 30},
+			{Label: "31d+", MinDays: 31, MaxDays: -1},
+		},
+	}
+
+	for i := range items {
+		age, ok := CapturedAge(items[i].Timestamp, now)
+		if !ok {
+			stats.Undated++
+			continue
+		}
+
+		if stats.Oldest == nil || age > stats.OldestAge {
+			stats.Oldest = &items[i]
+			stats.OldestAge = age
+		}
+
+		if maxAge > 0 && age > maxAge {
+			stats.Stale++
+		}
+
+		for b := range stats.Histogram {
+			bucket := &stats.Histogram[b]
+			if age >= bucket.MinDays && (bucket.MaxDays < 0 || age <= bucket.MaxDays) {
+				bucket.Count++
+				break
+			}
+		}
+	}
+
+	return stats
+}
+
+// FilterDumpOlderThan returns the dated items captured more than days ago
+func FilterDumpOlderThan(items []DumpItemJSON, days int, now time.Time) []DumpItemJSON {
+	result := make([]DumpItemJSON, 0, len(items))
+	for _, item := range items {
+		if age, ok := CapturedAge(item.Timestamp, now); ok && age > days {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// SortDumpByAge orders items oldest first; undated items keep their order at the end
+func SortDumpByAge(items []DumpItemJSON, now time.Time) {
+	sort.SliceStable(items, func(i, j int) bool {
+		ageI, okI := CapturedAge(items[i].Timestamp, now)
+		ageJ, okJ := CapturedAge(items[j].Timestamp, now)
+		if okI != okJ {
+			return okI
+		}
+		return ageI > ageJ
+	})
+}
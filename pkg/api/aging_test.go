@@ -0,0 +1,74 @@
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func agingTestItems() []DumpItemJSON {
+	return []DumpItemJSON{
+		{ID: "a", Content: "Fresh", Timestamp: "2024-03-10"},
+		{ID: "b", Content: "Undated"},
+		{ID: "c", Content: "Old", Timestamp: "2024-01-01"},
+		{ID: "d", Content: "Two weeks", Timestamp: "2024-02-24"},
+		{ID: "e", Content: "Last week", Timestamp: "2024-03-05"},
+	}
+}
+
+func TestComputeDumpStats(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+
+	stats := ComputeDumpStats(agingTestItems(), 7, now)
+
+	if stats.Count != 5 {
+		t.Errorf("Expected count 5, got %d", stats.Count)
+	}
+	if stats.Undated != 1 {
+		t.Errorf("Expected 1 undated item, got %d", stats.Undated)
+	}
+	if stats.Stale != 2 {
+		t.Errorf("Expected 2 stale items, got %d", stats.Stale)
+	}
+	if stats.Oldest == nil || stats.Oldest.ID != "c" || stats.OldestAge != 69 {
+		t.Errorf("Expected oldest item c (69 days), got %+v (%d days)", stats.Oldest, stats.OldestAge)
+	}
+
+	expected := []int{1, 1, 0, 1, 1}
+	for i, bucket := range stats.Histogram {
+		if bucket.Count != expected[i] {
+			t.Errorf("Bucket %s: expected %d, got %d", bucket.Label, expected[i], bucket.Count)
+		}
+	}
+
+	// Threshold 0 disables stale counting
+	if stats := ComputeDumpStats(agingTestItems(), 0, now); stats.Stale != 0 {
+		t.Errorf("Expected no stale items when disabled, got %d", stats.Stale)
+	}
+}
+
+func TestFilterDumpOlderThan(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+
+	result := FilterDumpOlderThan(agingTestItems(), 14, now)
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(result))
+	}
+	if result[0].ID != "c" || result[1].ID != "d" {
+		t.Errorf("Unexpected items: %s, %s", result[0].ID, result[1].ID)
+	}
+}
+
+func TestSortDumpByAge(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.Local)
+
+	items := agingTestItems()
+	SortDumpByAge(items, now)
+
+	var order string
+	for _, item := range items {
+		order += item.ID
+	}
+	if order != "cdeab" {
+		t.Errorf("Expected order cdeab, got %s", order)
+	}
+}
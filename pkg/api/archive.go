@@ -0,0 +1,246 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+)
+
+// Archive directories inside a brain
+const (
+	ArchiveDirName       = "99_archive"
+	LegacyArchiveDirName = "02_archive" // Used by older versions; migrated into ArchiveDirName
+)
+
+// archivedNamePattern matches <name>_<YYYYMMDD> with an optional -N collision counter
+var archivedNamePattern = regexp.MustCompile(`^(.+)_(\d{8})(?:-\d+)?$`)
+
+// ArchivedProject is a project in the archive directory
+type ArchivedProject struct {
+	Name       string `json:"name"` // Original project name
+	Dir        string `json:"dir"`  // Directory name in the archive, e.g. web_20240115
+	Path       string `json:"path"`
+	ArchivedOn string `json:"archived_on"`          // YYYY-MM-DD, empty if the name has no date suffix
+	TaskCount  int    `json:"task_count"`           // Tasks not done when archived
+	MetaError  string `json:"meta_error,omitempty"` // Why .project.json could not be read; defaults are used
+	ProjectMeta
+}
+
+// ArchivePath returns <brain>/99_archive
+func ArchivePath(brainPath string) string {
+	return filepath.Join(brainPath, ArchiveDirName)
+}
+
+// ArchiveProject moves a project from 01_active into the archive as <name>_<YYYYMMDD>
+// Projects in the legacy archive are migrated first. Returns the archive directory name.
+func ArchiveProject(brainPath, name string, now time.Time) (string, error) {
+	projectDir, err := FindProjectDir(filepath.Join(brainPath, ActiveDirName), name)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := MigrateLegacyArchive(brainPath); err != nil {
+		return "", err
+	}
+
+	archiveDir := ArchivePath(brainPath)
+	if err := fileutil.EnsureDir(archiveDir); err != nil {
+		return "", fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	dirName := uniqueDirName(archiveDir, fmt.Sprintf("%s_%s", name, now.Format("20060102")))
+	if err := os.Rename(projectDir, filepath.Join(archiveDir, dirName)); err != nil {
+		return "", fmt.Errorf("failed to archive project: %w", err)
+	}
+
+	return dirName, nil
+}
+
+// ListArchivedProjects returns archived projects, most recently archived first
+// Projects in the legacy archive are migrated first, so every archive command
+// (listing, restore, todo ls --archived) sees them.
+func ListArchivedProjects(brainPath string) ([]ArchivedProject, error) {
+	if _, err := MigrateLegacyArchive(brainPath); err != nil {
+		return nil, err
+	}
+
+	archiveDir := ArchivePath(brainPath)
+	entries, err := os.ReadDir(archiveDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive directory: %w", err)
+	}
+
+	var projects []ArchivedProject
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		project := ArchivedProject{
+			Name: entry.Name(),
+			Dir:  entry.Name(),
+			Path: filepath.Join(archiveDir, entry.Name()),
+		}
+		if m := archivedNamePattern.FindStringSubmatch(entry.Name()); m != nil {
+			if date, err := time.Parse("20060102", m[2]); err == nil {
+				project.Name = m[1]
+				project.ArchivedOn = date.Format("2006-01-02")
+			}
+		}
+
+		project.ProjectMeta, project.MetaError = loadProjectMetaOrDefault(project.Path)
+
+		if todos, err := parseTodoFile(filepath.Join(project.Path, "todo.md"), project.Name, false); err == nil {
+			project.TaskCount = len(todos)
+		}
+
+		projects = append(projects, project)
+	}
+
+	sort.SliceStable(projects, func(i, j int) bool {
+		if projects[i].ArchivedOn != projects[j].ArchivedOn {
+			return projects[i].ArchivedOn > projects[j].ArchivedOn
+		}
+		return projects[i].Dir < projects[j].Dir
+	})
+
+	return projects, nil
+}
+
+// FindArchivedProject finds an archived project by directory name or original name
+// An original name archived more than once is ambiguous and must be given as the directory name
+func FindArchivedProject(brainPath, query string) (*ArchivedProject, error) {
+	projects, err := ListArchivedProjects(brainPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []ArchivedProject
+	for _, project := range projects {
+		if project.Dir == query {
+			return &project, nil
+		}
+		if project.Name == query {
+			matches = append(matches, project)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("archived project '%s' not found", query)
+	case 1:
+		return &matches[0], nil
+	}
+
+	dirs := make([]string, len(matches))
+	for i, match := range matches {
+		dirs[i] = match.Dir
+	}
+	return nil, fmt.Errorf("'%s' was archived %d times, specify one of: %s", query, len(matches), strings.Join(dirs, ", "))
+}
+
+// RestoreProject moves an archived project back to 01_active
+// The date suffix is dropped; newName restores under a different name.
+func RestoreProject(brainPath string, project *ArchivedProject, newName string) (string, error) {
+	name := project.Name
+	if newName != "" {
+		name = newName
+	}
+
+	activeDir := filepath.Join(brainPath, ActiveDirName)
+	target := filepath.Join(activeDir, name)
+	if fileutil.FileExists(target) {
+		return "", fmt.Errorf("project '%s' already exists in %s (restore under another name with --as)", name, ActiveDirName)
+	}
+	if existing, err := FindProjectDir(activeDir, name); err == nil {
+		return "", fmt.Errorf("project '%s' already exists in %s (restore under another name with --as)", name, filepath.Base(filepath.Dir(existing)))
+	}
+
+	if err := fileutil.EnsureDir(activeDir); err != nil {
+		return "", fmt.Errorf("failed to create active directory: %w", err)
+	}
+	if err := os.Rename(project.Path, target); err != nil {
+		return "", fmt.Errorf("failed to restore project: %w", err)
+	}
+
+	return name, nil
+}
+
+// ParseArchivedTodos parses todo.md of every archived project
+// Tasks are attributed to the original project name and marked Archived.
+func ParseArchivedTodos(brainPath string, includeCompleted bool) ([]TodoItem, error) {
+	projects, err := ListArchivedProjects(brainPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var todos []TodoItem
+	for _, project := range projects {
+		fileTodos, err := parseTodoFile(filepath.Join(project.Path, "todo.md"), project.Name, includeCompleted)
+		if err != nil {
+			continue
+		}
+		for i := range fileTodos {
+			fileTodos[i].Source = "todo.md"
+			fileTodos[i].Archived = true
+		}
+		todos = append(todos, fileTodos...)
+	}
+
+	return todos, nil
+}
+
+// MigrateLegacyArchive moves projects from 02_archive into 99_archive
+// Name collisions get a -N suffix. The legacy directory is removed once empty.
+// Returns the number of projects moved.
+func MigrateLegacyArchive(brainPath string) (int, error) {
+	legacyDir := filepath.Join(brainPath, LegacyArchiveDirName)
+	entries, err := os.ReadDir(legacyDir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", LegacyArchiveDirName, err)
+	}
+
+	archiveDir := ArchivePath(brainPath)
+	if err := fileutil.EnsureDir(archiveDir); err != nil {
+		return 0, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	moved := 0
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		dirName := uniqueDirName(archiveDir, entry.Name())
+		if err := os.Rename(filepath.Join(legacyDir, entry.Name()), filepath.Join(archiveDir, dirName)); err != nil {
+			return moved, fmt.Errorf("failed to migrate %s: %w", entry.Name(), err)
+		}
+		moved++
+	}
+
+	// Only removes the directory if nothing (e.g. dotfiles) is left
+	_ = os.Remove(legacyDir)
+
+	return moved, nil
+}
+
+// uniqueDirName returns name, or name-2, name-3, ... if it already exists in dir
+func uniqueDirName(dir, name string) string {
+	candidate := name
+	for i := 2; fileutil.FileExists(filepath.Join(dir, candidate)); i++ {
+		candidate = name + "-" + strconv.Itoa(i)
+	}
+	return candidate
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestArchiveAndRestoreProject(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	webDir := tb.AddProject("web")
+	tb.WriteFile(filepath.Join(webDir, "todo.md"), "- [ ] First\n- [x] Done\n")
+
+	dir, err := ArchiveProject(tb.BrainPath, "web", now)
+	if err != nil {
+		t.Fatalf("ArchiveProject failed: %v", err)
+	}
+	if dir != "web_20240115" {
+		t.Errorf("Expected web_20240115, got %s", dir)
+	}
+
+	// Archiving a new project with the same name on the same day gets a counter
+	tb.AddProject("web")
+	dir, err = ArchiveProject(tb.BrainPath, "web", now)
+	if err != nil {
+		t.Fatalf("ArchiveProject failed: %v", err)
+	}
+	if dir != "web_20240115-2" {
+		t.Errorf("Expected web_20240115-2, got %s", dir)
+	}
+
+	projects, err := ListArchivedProjects(tb.BrainPath)
+	if err != nil {
+		t.Fatalf("ListArchivedProjects failed: %v", err)
+	}
+	if len(projects) != 2 {
+		t.Fatalf("Expected 2 archived projects, got %d", len(projects))
+	}
+	if projects[0].Name != "web" || projects[0].ArchivedOn != "2024-01-15" || projects[0].TaskCount != 1 {
+		t.Errorf("Unexpected archived project: %+v", projects[0])
+	}
+
+	// Ambiguous by name, exact by directory
+	if _, err := FindArchivedProject(tb.BrainPath, "web"); err == nil || !strings.Contains(err.Error(), "web_20240115-2") {
+		t.Errorf("Expected ambiguity error listing directories, got %v", err)
+	}
+	project, err := FindArchivedProject(tb.BrainPath, "web_20240115")
+	if err != nil {
+		t.Fatalf("FindArchivedProject failed: %v", err)
+	}
+
+	name, err := RestoreProject(tb.BrainPath, project, "")
+	if err != nil {
+		t.Fatalf("RestoreProject failed: %v", err)
+	}
+	if name != "web" || !tb.FileExists(filepath.Join(tb.ActiveDirPath, "web", "todo.md")) {
+		t.Errorf("Expected web restored to 01_active, got %s", name)
+	}
+
+	// Collision with an active project requires a new name
+	project, _ = FindArchivedProject(tb.BrainPath, "web")
+	if _, err := RestoreProject(tb.BrainPath, project, ""); err == nil {
+		t.Error("Expected collision error")
+	}
+	name, err = RestoreProject(tb.BrainPath, project, "web-old")
+	if err != nil || name != "web-old" {
+		t.Errorf("Expected restore as web-old, got %s (%v)", name, err)
+	}
+}
+
+func TestParseArchivedTodos(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	tb.WriteFile(filepath.Join(ArchivePath(tb.BrainPath), "api_20231201", "todo.md"), "- [ ] Leftover\n- [x] Shipped\n")
+
+	todos, err := ParseArchivedTodos(tb.BrainPath, false)
+	if err != nil {
+		t.Fatalf("ParseArchivedTodos failed: %v", err)
+	}
+	if len(todos) != 1 || todos[0].Project != "api" || !todos[0].Archived {
+		t.Errorf("Unexpected archived todos: %+v", todos)
+	}
+
+	todos, _ = ParseArchivedTodos(tb.BrainPath, true)
+	if len(todos) != 2 {
+		t.Errorf("Expected 2 todos including completed, got %d", len(todos))
+	}
+}
+
+func TestMigrateLegacyArchive(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	legacyDir := filepath.Join(tb.BrainPath, LegacyArchiveDirName)
+	tb.WriteFile(filepath.Join(legacyDir, "old_20230101", "todo.md"), "- [ ] a\n")
+	tb.WriteFile(filepath.Join(legacyDir, "dup_20230101", "todo.md"), "- [ ] legacy\n")
+	tb.WriteFile(filepath.Join(ArchivePath(tb.BrainPath), "dup_20230101", "todo.md"), "- [ ] current\n")
+
+	moved, err := MigrateLegacyArchive(tb.BrainPath)
+	if err != nil {
+		t.Fatalf("MigrateLegacyArchive failed: %v", err)
+	}
+	if moved != 2 {
+		t.Errorf("Expected 2 projects moved, got %d", moved)
+	}
+
+	if _, err := os.Stat(legacyDir); !os.IsNotExist(err) {
+		t.Error("Expected legacy archive directory to be removed")
+	}
+	for _, dir := range []string{"old_20230101", "dup_20230101", "dup_20230101-2"} {
+		if !tb.FileExists(filepath.Join(ArchivePath(tb.BrainPath), dir, "todo.md")) {
+			t.Errorf("Expected %s in archive", dir)
+		}
+	}
+
+	// Nothing left to migrate
+	if moved, err := MigrateLegacyArchive(tb.BrainPath); err != nil || moved != 0 {
+		t.Errorf("Expected no-op, got %d (%v)", moved, err)
+	}
+}
+
+func TestParseArchivedTodosMigratesLegacyArchive(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	tb.WriteFile(filepath.Join(tb.BrainPath, LegacyArchiveDirName, "old_20230101", "todo.md"), "- [ ] legacy task\n")
+
+	todos, err := ParseArchivedTodos(tb.BrainPath, false)
+	if err != nil {
+		t.Fatalf("ParseArchivedTodos failed: %v", err)
+	}
+	if len(todos) != 1 || todos[0].Project != "old" || !todos[0].Archived {
+		t.Errorf("Expected the legacy task, got %+v", todos)
+	}
+	if tb.FileExists(filepath.Join(tb.BrainPath, LegacyArchiveDirName)) {
+		t.Error("Expected legacy archive to be migrated")
+	}
+}
@@ -0,0 +1,299 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+)
+
+// AttachmentsDirName is the project directory holding attached files
+const AttachmentsDirName = "attachments"
+
+// MaxAttachmentSize is the largest file that is copied into a project
+const MaxAttachmentSize = 25 << 20
+
+// Attachment is a file in a project's attachments directory
+type Attachment struct {
+	Name       string   `json:"name"`
+	Path       string   `json:"path"`
+	Size       int64    `json:"size"`
+	References []string `json:"references"` // Project files linking to it, e.g. "notes/2024-01-15-sync.md"
+}
+
+var (
+	unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+	// markdownLinkPattern matches [text](target) and ![alt](target)
+	markdownLinkPattern = regexp.MustCompile(`(!?\[[^\]]*\]\()([^)\s]+)(\))`)
+
+	// referenceLinkPattern matches reference definitions: [label]: target
+	referenceLinkPattern = regexp.MustCompile(`(?m)^ {0,3}\[[^\]]+\]:[ \t]*<?([^\s>]+)>?`)
+
+	// htmlLinkPattern matches src= and href= attributes, e.g. <img src="attachments/a.png">
+	htmlLinkPattern = regexp.MustCompile(`(?i)\b(?:src|href)\s*=\s*["']([^"']+)["']`)
+
+	imageExtensions = map[string]bool{
+		".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true, ".webp": true,
+	}
+)
+
+// AddAttachment copies a file into <project>/attachments/
+// Files are de-duplicated by content: if an identical file is already attached
+// its name is returned with reused=true. Name clashes with different content
+// get a short hash suffix. Files larger than MaxAttachmentSize are refused.
+func AddAttachment(projectDir, srcPath string) (name string, reused bool, err error) {
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s: %w", srcPath, err)
+	}
+	if info.Size() > MaxAttachmentSize {
+		return "", false, fmt.Errorf("%s is larger than the %d MB attachment limit", srcPath, MaxAttachmentSize>>20)
+	}
+
+	data, err := os.ReadFile(srcPath)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s: %w", srcPath, err)
+	}
+	hash := contentHash(data)
+
+	dir := filepath.Join(projectDir, AttachmentsDirName)
+	if err := fileutil.EnsureDir(dir); err != nil {
+		return "", false, fmt.Errorf("failed to create attachments directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read attachments: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		// Only files of the same size can have the same content
+		if info, err := entry.Info(); err != nil || info.Size() != int64(len(data)) {
+			continue
+		}
+		existing, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err == nil && contentHash(existing) == hash {
+			return entry.Name(), true, nil
+		}
+	}
+
+	name = attachmentName(filepath.Base(srcPath))
+	if fileutil.FileExists(filepath.Join(dir, name)) {
+		ext := filepath.Ext(name)
+		name = fmt.Sprintf("%s-%s%s", strings.TrimSuffix(name, ext), hash[:8], ext)
+	}
+
+	if err := fileutil.AtomicWriteFile(filepath.Join(dir, name), data); err != nil {
+		return "", false, fmt.Errorf("failed to copy attachment: %w", err)
+	}
+
+	return name, false, nil
+}
+
+// AttachmentLink returns a markdown link to an attachment, relative to the note
+// Images use ![name](path) so they render inline
+func AttachmentLink(projectDir, notePath, name string) string {
+	target := filepath.Join(projectDir, AttachmentsDirName, name)
+	rel, err := filepath.Rel(filepath.Dir(notePath), target)
+	if err != nil {
+		rel = target
+	}
+	rel = filepath.ToSlash(rel)
+
+	if imageExtensions[strings.ToLower(filepath.Ext(name))] {
+		return fmt.Sprintf("![%s](%s)", name, rel)
+	}
+	return fmt.Sprintf("[%s](%s)", name, rel)
+}
+
+// ImportLinkedFiles attaches local files linked from note content
+// Links to absolute or ~/ paths of existing files are copied into the project's
+// attachments and rewritten relative to notePath. Other links, and files larger
+// than MaxAttachmentSize, are left alone. Only call this on explicit user action
+// (e.g. brain refile --import-files): it copies any file the content points to.
+// Returns the rewritten content and the attachment names.
+func ImportLinkedFiles(projectDir, notePath, content string) (string, []string, error) {
+	var imported []string
+	var importErr error
+
+	result := markdownLinkPattern.ReplaceAllStringFunc(content, func(match string) string {
+		if importErr != nil {
+			return match
+		}
+
+		parts := markdownLinkPattern.FindStringSubmatch(match)
+		src := expandHome(parts[2])
+		if !filepath.IsAbs(src) {
+			return match
+		}
+		if info, err := os.Stat(src); err != nil || !info.Mode().IsRegular() || info.Size() > MaxAttachmentSize {
+			return match
+		}
+
+		name, _, err := AddAttachment(projectDir, src)
+		if err != nil {
+			importErr = err
+			return match
+		}
+		imported = append(imported, name)
+
+		rel, err := filepath.Rel(filepath.Dir(notePath), filepath.Join(projectDir, AttachmentsDirName, name))
+		if err != nil {
+			return match
+		}
+		return parts[1] + filepath.ToSlash(rel) + parts[3]
+	})
+
+	if importErr != nil {
+		return content, nil, importErr
+	}
+	return result, imported, nil
+}
+
+// ListAttachments returns a project's attachments with the files referencing them
+// References are found in todo.md, notes.md and notes/*.md
+func ListAttachments(projectDir string) ([]Attachment, error) {
+	dir := filepath.Join(projectDir, AttachmentsDirName)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []Attachment{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachments: %w", err)
+	}
+
+	// Absolute link targets per referencing file
+	linked := map[string]map[string]bool{}
+	files := []string{"todo.md", "notes.md"}
+	notes, _ := filepath.Glob(filepath.Join(projectDir, "notes", "*.md"))
+	for _, note := range notes {
+		files = append(files, filepath.Join("notes", filepath.Base(note)))
+	}
+	for _, rel := range files {
+		path := filepath.Join(projectDir, rel)
+		if data, err := os.ReadFile(path); err == nil {
+			targets := map[string]bool{}
+			for _, target := range attachmentLinkTargets(path, string(data)) {
+				targets[target] = true
+			}
+			linked[filepath.ToSlash(rel)] = targets
+		}
+	}
+
+	attachments := []Attachment{}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		attachment := Attachment{
+			Name:       entry.Name(),
+			Path:       filepath.Join(dir, entry.Name()),
+			Size:       info.Size(),
+			References: []string{},
+		}
+		for rel, targets := range linked {
+			if targets[attachment.Path] {
+				attachment.References = append(attachment.References, rel)
+			}
+		}
+		sort.Strings(attachment.References)
+
+		attachments = append(attachments, attachment)
+	}
+
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].Name < attachments[j].Name
+	})
+
+	return attachments, nil
+}
+
+// PruneAttachments deletes attachments that no project file references
+// Returns the unreferenced attachments; with dryRun nothing is deleted
+func PruneAttachments(projectDir string, dryRun bool) ([]Attachment, error) {
+	attachments, err := ListAttachments(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var unreferenced []Attachment
+	for _, attachment := range attachments {
+		if len(attachment.References) > 0 {
+			continue
+		}
+		if !dryRun {
+			if err := os.Remove(attachment.Path); err != nil {
+				return unreferenced, fmt.Errorf("failed to delete %s: %w", attachment.Name, err)
+			}
+		}
+		unreferenced = append(unreferenced, attachment)
+	}
+
+	return unreferenced, nil
+}
+
+// attachmentLinkTargets returns the attachment files linked from content
+// Inline links, reference definitions ([x]: target) and HTML src/href
+// attributes count. Link targets are resolved relative to sourcePath; only
+// files directly in an attachments directory are returned.
+func attachmentLinkTargets(sourcePath, content string) []string {
+	var links []string
+	for _, match := range markdownLinkPattern.FindAllStringSubmatch(content, -1) {
+		links = append(links, match[2])
+	}
+	for _, pattern := range []*regexp.Regexp{referenceLinkPattern, htmlLinkPattern} {
+		for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+			links = append(links, match[1])
+		}
+	}
+
+	var targets []string
+	for _, link := range links {
+		target, _, _ := strings.Cut(link, "#")
+		if target == "" || strings.Contains(target, "://") {
+			continue
+		}
+		path := filepath.Clean(filepath.Join(filepath.Dir(sourcePath), filepath.FromSlash(target)))
+		if filepath.Base(filepath.Dir(path)) == AttachmentsDirName {
+			targets = append(targets, path)
+		}
+	}
+	return targets
+}
+
+// attachmentName makes a file name safe to use in markdown links
+func attachmentName(name string) string {
+	name = unsafeNameChars.ReplaceAllString(name, "-")
+	name = strings.Trim(name, "-")
+	if name == "" || name == "." || name == ".." {
+		return "attachment"
+	}
+	return name
+}
+
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// expandHome replaces a leading ~/ with the home directory
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(os.Getenv("HOME"), path[2:])
+	}
+	return path
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestAddAttachment(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	projectDir := tb.AddProject("web")
+
+	shot := filepath.Join(tb.TmpDir, "my shot.png")
+	tb.WriteFile(shot, "image-bytes")
+
+	name, reused, err := AddAttachment(projectDir, shot)
+	if err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+	if name != "my-shot.png" || reused {
+		t.Errorf("Expected new attachment my-shot.png, got %s (reused=%v)", name, reused)
+	}
+
+	// Same content under another name is de-duplicated
+	copyPath := filepath.Join(tb.TmpDir, "copy.png")
+	tb.WriteFile(copyPath, "image-bytes")
+	name, reused, err = AddAttachment(projectDir, copyPath)
+	if err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+	if name != "my-shot.png" || !reused {
+		t.Errorf("Expected reuse of my-shot.png, got %s (reused=%v)", name, reused)
+	}
+
+	// Same name with different content gets a hash suffix
+	other := filepath.Join(tb.TmpDir, "other", "my shot.png")
+	tb.WriteFile(other, "different-bytes")
+	name, _, err = AddAttachment(projectDir, other)
+	if err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+	if !strings.HasPrefix(name, "my-shot-") || !strings.HasSuffix(name, ".png") {
+		t.Errorf("Expected hash-suffixed name, got %s", name)
+	}
+
+	entries, _ := os.ReadDir(filepath.Join(projectDir, AttachmentsDirName))
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 attachments on disk, got %d", len(entries))
+	}
+
+	// Files over the size limit are refused
+	big := filepath.Join(tb.TmpDir, "big.bin")
+	tb.WriteFile(big, "")
+	if err := os.Truncate(big, MaxAttachmentSize+1); err != nil {
+		t.Fatalf("Failed to grow big file: %v", err)
+	}
+	if _, _, err := AddAttachment(projectDir, big); err == nil {
+		t.Error("Expected error for file over the size limit")
+	}
+}
+
+func TestAttachmentLink(t *testing.T) {
+	projectDir := "/brain/01_active/web"
+
+	tests := []struct {
+		notePath string
+		name     string
+		expected string
+	}{
+		{projectDir + "/notes/sync.md", "shot.png", "![shot.png](../attachments/shot.png)"},
+		{projectDir + "/notes.md", "crash.log", "[crash.log](attachments/crash.log)"},
+	}
+
+	for _, tt := range tests {
+		if got := AttachmentLink(projectDir, tt.notePath, tt.name); got != tt.expected {
+			t.Errorf("AttachmentLink(%s, %s) = %s, want %s", tt.notePath, tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestImportLinkedFiles(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	projectDir := tb.AddProject("web")
+
+	logPath := filepath.Join(tb.TmpDir, "crash.log")
+	tb.WriteFile(logPath, "stack trace")
+
+	notePath := filepath.Join(projectDir, "notes", "incident.md")
+	big := filepath.Join(tb.TmpDir, "big.bin")
+	tb.WriteFile(big, "")
+	if err := os.Truncate(big, MaxAttachmentSize+1); err != nil {
+		t.Fatalf("Failed to grow big file: %v", err)
+	}
+
+	content := "See ![trace](" + logPath + "), [docs](https://go.dev), [dump](" + big + ") and [gone](/does/not/exist.txt)"
+
+	result, imported, err := ImportLinkedFiles(projectDir, notePath, content)
+	if err != nil {
+		t.Fatalf("ImportLinkedFiles failed: %v", err)
+	}
+
+	expected := "See ![trace](../attachments/crash.log), [docs](https://go.dev), [dump](" + big + ") and [gone](/does/not/exist.txt)"
+	if result != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, result)
+	}
+	if len(imported) != 1 || imported[0] != "crash.log" {
+		t.Errorf("Expected crash.log imported, got %v", imported)
+	}
+}
+
+func TestListAndPruneAttachments(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	projectDir := tb.AddProject("web")
+
+	attachments, err := ListAttachments(projectDir)
+	if err != nil || len(attachments) != 0 {
+		t.Fatalf("Expected no attachments, got %v (err=%v)", attachments, err)
+	}
+
+	dir := filepath.Join(projectDir, AttachmentsDirName)
+	tb.WriteFile(filepath.Join(dir, "used.png"), "a")
+	tb.WriteFile(filepath.Join(dir, "task.pdf"), "b")
+	tb.WriteFile(filepath.Join(dir, "orphan.log"), "c")
+	tb.WriteFile(filepath.Join(dir, "a.png"), "d")
+	tb.WriteFile(filepath.Join(dir, "ref.png"), "e")
+	tb.WriteFile(filepath.Join(dir, "html.png"), "f")
+	tb.WriteFile(filepath.Join(projectDir, "notes", "sync.md"), "# Sync\n\n![used.png](../attachments/used.png)\n[data](../attachments/data.png)\n")
+	tb.WriteFile(filepath.Join(projectDir, "notes.md"), "![Logo][logo]\n<img src=\"attachments/html.png\" width=\"200\">\n\n[logo]: <attachments/ref.png> \"Logo\"\n")
+	tb.WriteFile(filepath.Join(projectDir, "todo.md"), "# Tasks\n\n- [ ] Review [spec](attachments/task.pdf)\n")
+
+	attachments, err = ListAttachments(projectDir)
+	if err != nil {
+		t.Fatalf("ListAttachments failed: %v", err)
+	}
+	refs := map[string]string{}
+	for _, a := range attachments {
+		refs[a.Name] = strings.Join(a.References, ",")
+	}
+	if refs["used.png"] != "notes/sync.md" || refs["task.pdf"] != "todo.md" || refs["orphan.log"] != "" || refs["a.png"] != "" ||
+		refs["ref.png"] != "notes.md" || refs["html.png"] != "notes.md" {
+		t.Errorf("Unexpected references: %v", refs)
+	}
+
+	pruned, err := PruneAttachments(projectDir, true)
+	if err != nil {
+		t.Fatalf("PruneAttachments dry run failed: %v", err)
+	}
+	if len(pruned) != 2 || !tb.FileExists(filepath.Join(dir, "orphan.log")) {
+		t.Fatalf("Dry run should report a.png and orphan.log without deleting them, got %v", pruned)
+	}
+
+	pruned, err = PruneAttachments(projectDir, false)
+	if err != nil {
+		t.Fatalf("PruneAttachments failed: %v", err)
+	}
+	if len(pruned) != 2 || pruned[1].Name != "orphan.log" || tb.FileExists(filepath.Join(dir, "orphan.log")) {
+		t.Errorf("Expected a.png and orphan.log to be deleted, got %v", pruned)
+	}
+	if !tb.FileExists(filepath.Join(dir, "used.png")) || !tb.FileExists(filepath.Join(dir, "task.pdf")) {
+		t.Error("Referenced attachments must be kept")
+	}
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/dateutil"
+)
+
+// CaptureInput represents captured text after inline metadata has been parsed
+type CaptureInput struct {
+	Text    string // Task text with metadata normalized to #due:YYYY-MM-DD and #p:N
+	Project string // Target project from +project, empty to capture into the dump
+}
+
+var (
+	captureDuePattern      = regexp.MustCompile(`^#?due:(.+)$`)
+	capturePriorityPattern = regexp.MustCompile(`^!([1-3])$`)
+	captureProjectPattern  = regexp.MustCompile(`^\+([a-zA-Z][a-zA-Z0-9_-]*)$`)
+)
+
+// ParseCaptureText parses inline metadata from quick-capture text
+// Supports:
+//   - #due:<date> or due:<date> with any format accepted by dateutil.ParseNaturalDate
+//   - !1, !2, !3 as shorthand for #p:1, #p:2, #p:3
+//   - +project to route the task to a project instead of the dump
+func ParseCaptureText(text string) (CaptureInput, error) {
+	var input CaptureInput
+	var words []string
+
+	for _, word := range strings.Fields(text) {
+		if matches := captureDuePattern.FindStringSubmatch(word); matches != nil {
+			dueDate, err := dateutil.ParseNaturalDate(matches[1])
+			if err != nil {
+				return CaptureInput{}, fmt.Errorf("invalid due date '%s': %w", matches[1], err)
+			}
+			words = append(words, "#due:"+dueDate)
+			continue
+		}
+
+		if matches := capturePriorityPattern.FindStringSubmatch(word); matches != nil {
+			words = append(words, "#p:"+matches[1])
+			continue
+		}
+
+		if matches := captureProjectPattern.FindStringSubmatch(word); matches != nil {
+			if input.Project != "" {
+				return CaptureInput{}, fmt.Errorf("multiple projects specified: +%s and +%s", input.Project, matches[1])
+			}
+			input.Project = matches[1]
+			continue
+		}
+
+		words = append(words, word)
+	}
+
+	input.Text = strings.Join(words, " ")
+	if input.Text == "" {
+		return CaptureInput{}, fmt.Errorf("empty capture text")
+	}
+
+	return input, nil
+}
+
+// MatchProjectName resolves a possibly abbreviated project name against a list of projects
+// Tries an exact match, then a case-insensitive match, then a unique prefix or substring match
+func MatchProjectName(projects []string, query string) (string, error) {
+	lowerQuery := strings.ToLower(query)
+
+	for _, project := range projects {
+		if project == query {
+			return project, nil
+		}
+	}
+
+	for _, project := range projects {
+		if strings.ToLower(project) == lowerQuery {
+			return project, nil
+		}
+	}
+
+	var prefixMatches, substringMatches []string
+	for _, project := range projects {
+		lowerProject := strings.ToLower(project)
+		if strings.HasPrefix(lowerProject, lowerQuery) {
+			prefixMatches = append(prefixMatches, project)
+		} else if strings.Contains(lowerProject, lowerQuery) {
+			substringMatches = append(substringMatches, project)
+		}
+	}
+
+	for _, matches := range [][]string{prefixMatches, substringMatches} {
+		if len(matches) == 1 {
+			return matches[0], nil
+		}
+		if len(matches) > 1 {
+			sort.Strings(matches)
+			return "", fmt.Errorf("project '%s' is ambiguous (matches: %s)", query, strings.Join(matches, ", "))
+		}
+	}
+
+	return "", fmt.Errorf("project '%s' not found", query)
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCaptureText(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+
+	tests := []struct {
+		name            string
+		input           string
+		expectedText    string
+		expectedProject string
+	}{
+		{
+			name:         "plain text",
+			input:        "Fix the authentication bug",
+			expectedText: "Fix the authentication bug",
+		},
+		{
+			name:         "natural due date with hash",
+			input:        "call bank #due:tomorrow",
+			expectedText: "call bank #due:" + tomorrow,
+		},
+		{
+			name:         "natural due date without hash",
+			input:        "call bank due:tomorrow",
+			expectedText: "call bank #due:" + tomorrow,
+		},
+		{
+			name:         "ISO due date",
+			input:        "file taxes #due:2026-04-30",
+			expectedText: "file taxes #due:2026-04-30",
+		},
+		{
+			name:         "priority shorthand",
+			input:        "!1 deploy hotfix",
+			expectedText: "#p:1 deploy hotfix",
+		},
+		{
+			name:            "project routing",
+			input:           "+work review PR #bug",
+			expectedText:    "review PR #bug",
+			expectedProject: "work",
+		},
+		{
+			name:         "numeric plus is not a project",
+			input:        "give +1 on proposal",
+			expectedText: "give +1 on proposal",
+		},
+		{
+			name:         "exclamation outside range is kept",
+			input:        "ship it !4",
+			expectedText: "ship it !4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseCaptureText(tt.input)
+			if err != nil {
+				t.Fatalf("ParseCaptureText failed: %v", err)
+			}
+			if result.Text != tt.expectedText {
+				t.Errorf("Expected text '%s', got '%s'", tt.expectedText, result.Text)
+			}
+			if result.Project != tt.expectedProject {
+				t.Errorf("Expected project '%s', got '%s'", tt.expectedProject, result.Project)
+			}
+		})
+	}
+}
+
+func TestParseCaptureText_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"invalid due date", "call bank #due:someday"},
+		{"multiple projects", "+work +home do things"},
+		{"only metadata", "+work"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCaptureText(tt.input); err == nil {
+				t.Errorf("Expected error for input '%s'", tt.input)
+			}
+		})
+	}
+}
+
+func TestMatchProjectName(t *testing.T) {
+	projects := []string{"backend-api", "frontend", "infra", "Infra-legacy"}
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"infra", "infra"},
+		{"INFRA", "infra"},
+		{"back", "backend-api"},
+		{"front", "frontend"},
+		{"api", "backend-api"},
+		{"legacy", "Infra-legacy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			result, err := MatchProjectName(projects, tt.query)
+			if err != nil {
+				t.Fatalf("MatchProjectName failed: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMatchProjectName_Errors(t *testing.T) {
+	projects := []string{"backend-api", "backend-worker", "frontend"}
+
+	_, err := MatchProjectName(projects, "backend")
+	if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("Expected ambiguous error, got %v", err)
+	}
+
+	_, err = MatchProjectName(projects, "mobile")
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,309 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+)
+
+// CodeRefGoneTag flags tasks whose source comment no longer exists
+const CodeRefGoneTag = "ref-gone"
+
+// maxScanFileSize skips generated or vendored blobs
+const maxScanFileSize = 1 << 20
+
+// CodeComment is a TODO/FIXME/HACK comment found in a repository
+type CodeComment struct {
+	Repo string // Repository directory name
+	Path string // Path relative to the repository root
+	Line int
+	Kind string // "TODO", "FIXME" or "HACK"
+	Text string
+}
+
+var (
+	// Comment markers: //, #, /*, *, --, ;, <!--
+	codeCommentPattern = regexp.MustCompile(`(?:^|[^\w:])(?://+|#+|/\*+|\*|--|;+|<!--)\s*(TODO|FIXME|HACK)\b(?:\([^)]*\))?:?\s*(.*)$`)
+	// In markdown # starts a heading ("# TODO list"), not a comment
+	markdownCommentPattern = regexp.MustCompile(`(?:^|[^\w:])(?://+|/\*+|\*|--|;+|<!--)\s*(TODO|FIXME|HACK)\b(?:\([^)]*\))?:?\s*(.*)$`)
+	codeRefPattern         = regexp.MustCompile(`#ref:(\S+):(\d+)`)
+	hashTokenPattern       = regexp.MustCompile(`#\S+`)
+)
+
+// Ref returns the #ref: value for the comment: <repo>/<path>:<line>
+func (c CodeComment) Ref() string {
+	return fmt.Sprintf("%s/%s:%d", c.Repo, filepath.ToSlash(c.Path), c.Line)
+}
+
+// TaskLine formats the comment as a todo.md task with a back-reference
+func (c CodeComment) TaskLine() string {
+	return fmt.Sprintf("- [ ] %s #%s #ref:%s", c.Text, strings.ToLower(c.Kind), c.Ref())
+}
+
+// key identifies a comment independently of its line number
+// Identical comments in one file share a key; SyncCodeTasks pairs them with
+// tasks by count.
+func (c CodeComment) key() string {
+	return c.Repo + "/" + filepath.ToSlash(c.Path) + "\x00" + normalizeCodeText(c.Text)
+}
+
+// ParseCodeComment extracts a TODO/FIXME/HACK comment from a source line
+func ParseCodeComment(line string) (kind, text string, ok bool) {
+	return parseCodeComment(codeCommentPattern, line)
+}
+
+func parseCodeComment(pattern *regexp.Regexp, line string) (kind, text string, ok bool) {
+	matches := pattern.FindStringSubmatch(line)
+	if matches == nil {
+		return "", "", false
+	}
+
+	text = strings.TrimSpace(matches[2])
+	text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(text, "*/"), "-->"))
+	return matches[1], text, true
+}
+
+// ScanRepoComments finds TODO/FIXME/HACK comments in the given repository files
+// Binary and very large files are skipped. In markdown files # markers are
+// headings and are ignored.
+func ScanRepoComments(repoPath string, files []string) ([]CodeComment, error) {
+	repo := filepath.Base(repoPath)
+	var comments []CodeComment
+
+	for _, rel := range files {
+		path := filepath.Join(repoPath, rel)
+
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() || info.Size() > maxScanFileSize {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+
+		// Binary files contain NUL bytes
+		if bytes.IndexByte(data, 0) >= 0 {
+			continue
+		}
+
+		pattern := codeCommentPattern
+		switch strings.ToLower(filepath.Ext(rel)) {
+		case ".md", ".markdown":
+			pattern = markdownCommentPattern
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), maxScanFileSize)
+		lineNum := 0
+		for scanner.Scan() {
+			lineNum++
+			kind, text, ok := parseCodeComment(pattern, scanner.Text())
+			if !ok {
+				continue
+			}
+			if text == "" {
+				text = fmt.Sprintf("%s in %s", kind, filepath.Base(rel))
+			}
+			comments = append(comments, CodeComment{Repo: repo, Path: rel, Line: lineNum, Kind: kind, Text: text})
+		}
+	}
+
+	return comments, nil
+}
+
+// CodeScanResult summarizes how scanned comments relate to existing tasks
+type CodeScanResult struct {
+	New     []CodeComment // Comments without a task yet
+	Moved   int           // Tasks whose #ref: line number was updated
+	Gone    []string      // Open tasks whose comment disappeared (newly flagged)
+	Revived int           // Flagged tasks whose comment came back
+}
+
+// SyncCodeTasks reconciles scanned comments with the tasks in a todo.md file
+// Existing tasks are matched by file and comment text, so re-scanning never
+// creates duplicates; identical comments in one file each get their own task. Line numbers are updated when code moves, open tasks are
+// tagged #ref-gone when their comment disappears, and new comments are added
+// as tasks in the Active section. Only tasks referencing one of the scanned
+// repos are considered. With dryRun the file is left untouched.
+func SyncCodeTasks(todoPath string, comments []CodeComment, scannedRepos []string, dryRun bool) (CodeScanResult, error) {
+	var result CodeScanResult
+
+	apply := func() error {
+		content := ""
+		if data, err := os.ReadFile(todoPath); err == nil {
+			content = string(data)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read todo.md: %w", err)
+		}
+
+		lines := strings.Split(content, "\n")
+		if content == "" {
+			lines = []string{"# Tasks", "", "## Active", "", "## Completed", ""}
+		}
+
+		// Comments sharing a key are handed out to tasks in scan order
+		byKey := make(map[string][]CodeComment)
+		for _, comment := range comments {
+			byKey[comment.key()] = append(byKey[comment.key()], comment)
+		}
+
+		scanned := make(map[string]bool)
+		for _, repo := range scannedRepos {
+			scanned[repo] = true
+		}
+
+		claimed := make(map[string]int) // Comments per key that have a task
+		changed := false
+
+		for i, line := range lines {
+			matches := codeRefPattern.FindStringSubmatch(line)
+			if matches == nil {
+				continue
+			}
+
+			checkbox, text, ok := splitTaskLine(line)
+			if !ok {
+				continue
+			}
+
+			refPath := matches[1]
+			repo, _, _ := strings.Cut(refPath, "/")
+			if !scanned[repo] {
+				continue
+			}
+
+			key := refPath + "\x00" + normalizeCodeText(text)
+			var comment CodeComment
+			found := claimed[key] < len(byKey[key])
+			if found {
+				comment = byKey[key][claimed[key]]
+				claimed[key]++
+			}
+			flagged := hasTag(line, CodeRefGoneTag)
+			newLine := line
+
+			switch {
+			case found:
+				if matches[0] != "#ref:"+comment.Ref() {
+					newLine = strings.Replace(newLine, matches[0], "#ref:"+comment.Ref(), 1)
+					result.Moved++
+				}
+				if flagged {
+					newLine = removeTag(newLine, CodeRefGoneTag)
+					result.Revived++
+				}
+			case !flagged && checkbox != "x" && checkbox != "X":
+				newLine = line + " #" + CodeRefGoneTag
+				result.Gone = append(result.Gone, strings.Join(strings.Fields(hashTokenPattern.ReplaceAllString(text, "")), " "))
+			}
+
+			if newLine != line {
+				lines[i] = newLine
+				changed = true
+			}
+		}
+
+		var newTasks []string
+		for _, comment := range comments {
+			key := comment.key()
+			if claimed[key] >= len(byKey[key]) {
+				continue
+			}
+			comment = byKey[key][claimed[key]]
+			claimed[key]++
+			result.New = append(result.New, comment)
+			newTasks = append(newTasks, comment.TaskLine())
+		}
+
+		if len(newTasks) > 0 {
+			lines = insertActiveTasks(lines, newTasks)
+			changed = true
+		}
+
+		if dryRun || !changed {
+			return nil
+		}
+
+		return fileutil.AtomicWriteFile(todoPath, []byte(strings.Join(lines, "\n")))
+	}
+
+	if dryRun {
+		return result, apply()
+	}
+	return result, fileutil.WithLock(todoPath, apply)
+}
+
+// splitTaskLine returns the checkbox character and text of a task line without tags
+func splitTaskLine(line string) (string, string, bool) {
+	matches := taskLinePattern.FindStringSubmatch(line)
+	if matches == nil {
+		return "", "", false
+	}
+	return matches[1], matches[2], true
+}
+
+var taskLinePattern = regexp.MustCompile(`^\s*- \[(.)\] (.+)$`)
+
+// insertActiveTasks adds task lines at the end of the ## Active section
+// Without an Active section the tasks are appended to the end of the file
+func insertActiveTasks(lines, tasks []string) []string {
+	active := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "## Active" {
+			active = i
+			break
+		}
+	}
+
+	insertAt := len(lines)
+	if active >= 0 {
+		for i := active + 1; i < len(lines); i++ {
+			if strings.HasPrefix(lines[i], "## ") {
+				insertAt = i
+				break
+			}
+		}
+	}
+
+	// Insert after the last non-blank line of the section
+	for insertAt > active+1 && insertAt > 0 && strings.TrimSpace(lines[insertAt-1]) == "" {
+		insertAt--
+	}
+
+	result := make([]string, 0, len(lines)+len(tasks)+1)
+	result = append(result, lines[:insertAt]...)
+	if active >= 0 && insertAt == active+1 {
+		result = append(result, "")
+	}
+	result = append(result, tasks...)
+	result = append(result, lines[insertAt:]...)
+	return result
+}
+
+// normalizeCodeText reduces task or comment text to a comparable form
+func normalizeCodeText(text string) string {
+	text = hashTokenPattern.ReplaceAllString(text, "")
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+func hasTag(line, tag string) bool {
+	for _, field := range strings.Fields(line) {
+		if field == "#"+tag {
+			return true
+		}
+	}
+	return false
+}
+
+func removeTag(line, tag string) string {
+	pattern := regexp.MustCompile(`\s+#` + regexp.QuoteMeta(tag) + `(\s|$)`)
+	return pattern.ReplaceAllString(line, "$1")
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestParseCodeComment(t *testing.T) {
+	tests := []struct {
+		line         string
+		expectedKind string
+		expectedText string
+		ok           bool
+	}{
+		{"	// TODO: handle timeout", "TODO", "handle timeout", true},
+		{"x := 1 // FIXME(alice): off by one", "FIXME", "off by one", true},
+		{"# HACK work around upstream bug", "HACK", "work around upstream bug", true},
+		{"/* TODO: free buffer */", "TODO", "free buffer", true},
+		{"-- TODO migrate column", "TODO", "migrate column", true},
+		{"<!-- TODO: fix layout -->", "TODO", "fix layout", true},
+		{"// TODO", "TODO", "", true},
+		{"todoList := []string{}", "", "", false},
+		{`url := "http://example.com/TODO"`, "", "", false},
+		{"// Todo: lowercase is ignored", "", "", false},
+		{"// TODOS are not markers", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			kind, text, ok := ParseCodeComment(tt.line)
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if kind != tt.expectedKind || text != tt.expectedText {
+				t.Errorf("Expected (%s, %s), got (%s, %s)", tt.expectedKind, tt.expectedText, kind, text)
+			}
+		})
+	}
+}
+
+func TestScanRepoComments(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	repo := filepath.Join(tb.TmpDir, "api")
+	tb.WriteFile(filepath.Join(repo, "main.go"), "package main\n\n// TODO: add flags\nfunc main() {}\n")
+	tb.WriteFile(filepath.Join(repo, "data.bin"), "\x00\x01// TODO: binary\n")
+	tb.WriteFile(filepath.Join(repo, "README.md"), "# TODO list\n\n<!-- TODO: document flags -->\n")
+
+	comments, err := ScanRepoComments(repo, []string{"main.go", "data.bin", "missing.go", "README.md"})
+	if err != nil {
+		t.Fatalf("ScanRepoComments failed: %v", err)
+	}
+
+	if len(comments) != 2 {
+		t.Fatalf("Expected 2 comments, got %+v", comments)
+	}
+	if comments[0].Ref() != "api/main.go:3" || comments[0].Text != "add flags" {
+		t.Errorf("Unexpected comment: %+v", comments[0])
+	}
+	// Markdown headings are not comments
+	if comments[1].Ref() != "api/README.md:3" || comments[1].Text != "document flags" {
+		t.Errorf("Unexpected markdown comment: %+v", comments[1])
+	}
+}
+
+func TestSyncCodeTasks(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	projectDir := tb.AddProject("backend")
+	todoPath := filepath.Join(projectDir, "todo.md")
+	tb.WriteFile(todoPath, "# Tasks\n\n## Active\n\n- [ ] Existing task\n\n## Completed\n\n- [x] Old #todo #ref:api/old.go:1\n")
+
+	comments := []CodeComment{
+		{Repo: "api", Path: "main.go", Line: 3, Kind: "TODO", Text: "add flags"},
+		{Repo: "api", Path: "db.go", Line: 10, Kind: "FIXME", Text: "close rows"},
+	}
+
+	// First run adds both comments as tasks in the Active section
+	result, err := SyncCodeTasks(todoPath, comments, []string{"api"}, false)
+	if err != nil {
+		t.Fatalf("SyncCodeTasks failed: %v", err)
+	}
+	if len(result.New) != 2 {
+		t.Fatalf("Expected 2 new tasks, got %d", len(result.New))
+	}
+
+	content := tb.ReadFile(todoPath)
+	expected := "- [ ] Existing task\n- [ ] add flags #todo #ref:api/main.go:3\n- [ ] close rows #fixme #ref:api/db.go:10\n\n## Completed"
+	if !strings.Contains(content, expected) {
+		t.Errorf("Tasks not added to Active section:\n%s", content)
+	}
+
+	// Re-scan after code moved and one comment was removed
+	comments = []CodeComment{{Repo: "api", Path: "main.go", Line: 7, Kind: "TODO", Text: "add flags"}}
+	result, err = SyncCodeTasks(todoPath, comments, []string{"api"}, false)
+	if err != nil {
+		t.Fatalf("SyncCodeTasks failed: %v", err)
+	}
+	if len(result.New) != 0 || result.Moved != 1 || len(result.Gone) != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+
+	content = tb.ReadFile(todoPath)
+	if !strings.Contains(content, "- [ ] add flags #todo #ref:api/main.go:7\n") {
+		t.Errorf("Line number not updated:\n%s", content)
+	}
+	if !strings.Contains(content, "- [ ] close rows #fixme #ref:api/db.go:10 #ref-gone\n") {
+		t.Errorf("Removed comment not flagged:\n%s", content)
+	}
+	if strings.Contains(content, "old.go:1 #ref-gone") {
+		t.Errorf("Completed task should not be flagged:\n%s", content)
+	}
+
+	// Comment comes back: flag is removed, nothing duplicated
+	comments = append(comments, CodeComment{Repo: "api", Path: "db.go", Line: 12, Kind: "FIXME", Text: "close rows"})
+	result, err = SyncCodeTasks(todoPath, comments, []string{"api"}, false)
+	if err != nil {
+		t.Fatalf("SyncCodeTasks failed: %v", err)
+	}
+	if len(result.New) != 0 || result.Revived != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if content := tb.ReadFile(todoPath); !strings.Contains(content, "- [ ] close rows #fixme #ref:api/db.go:12\n") {
+		t.Errorf("Revived task not restored:\n%s", content)
+	}
+}
+
+func TestSyncCodeTasks_IdenticalComments(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	projectDir := tb.AddProject("backend")
+	todoPath := filepath.Join(projectDir, "todo.md")
+
+	comments := []CodeComment{
+		{Repo: "api", Path: "db.go", Line: 4, Kind: "TODO", Text: "handle error"},
+		{Repo: "api", Path: "db.go", Line: 9, Kind: "TODO", Text: "handle error"},
+	}
+
+	// Each occurrence gets its own task
+	result, err := SyncCodeTasks(todoPath, comments, []string{"api"}, false)
+	if err != nil {
+		t.Fatalf("SyncCodeTasks failed: %v", err)
+	}
+	if len(result.New) != 2 {
+		t.Fatalf("Expected 2 new tasks, got %+v", result)
+	}
+
+	// Re-scanning adds nothing
+	result, err = SyncCodeTasks(todoPath, comments, []string{"api"}, false)
+	if err != nil {
+		t.Fatalf("SyncCodeTasks failed: %v", err)
+	}
+	if len(result.New) != 0 || result.Moved != 0 || len(result.Gone) != 0 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+
+	// One occurrence removed: one task is flagged
+	result, err = SyncCodeTasks(todoPath, comments[:1], []string{"api"}, false)
+	if err != nil {
+		t.Fatalf("SyncCodeTasks failed: %v", err)
+	}
+	if len(result.Gone) != 1 {
+		t.Errorf("Expected 1 gone task, got %+v", result)
+	}
+	content := tb.ReadFile(todoPath)
+	if !strings.Contains(content, "#ref:api/db.go:4\n") || !strings.Contains(content, "#ref:api/db.go:9 #ref-gone") {
+		t.Errorf("Unexpected tasks:\n%s", content)
+	}
+}
+
+func TestSyncCodeTasks_DryRunAndUnscannedRepos(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	projectDir := tb.AddProject("backend")
+	todoPath := filepath.Join(projectDir, "todo.md")
+	original := "# Tasks\n\n## Active\n\n- [ ] From web #todo #ref:web/app.js:4\n\n## Completed\n"
+	tb.WriteFile(todoPath, original)
+
+	comments := []CodeComment{{Repo: "api", Path: "main.go", Line: 3, Kind: "TODO", Text: "add flags"}}
+
+	result, err := SyncCodeTasks(todoPath, comments, []string{"api"}, true)
+	if err != nil {
+		t.Fatalf("SyncCodeTasks failed: %v", err)
+	}
+	if len(result.New) != 1 || len(result.Gone) != 0 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if content := tb.ReadFile(todoPath); content != original {
+		t.Errorf("Dry run modified todo.md:\n%s", content)
+	}
+}
@@ -0,0 +1,260 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+)
+
+// ActiveDirName is the container for active projects
+const ActiveDirName = "01_active"
+
+// containerPattern matches top-level container directories such as 02_areas
+var containerPattern = regexp.MustCompile(`^(\d{2})_([a-z0-9][a-z0-9-]*)$`)
+
+// validContainerName restricts the short name of a new container
+var validContainerName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Container is a top-level brain directory holding projects (PARA layout)
+// 01_active is always a container; any other NN_<name> directory except
+// the archive is one too, e.g. 02_areas for ongoing responsibilities or
+// 03_resources for reference material.
+type Container struct {
+	Name string `json:"name"` // Short name, e.g. "areas"
+	Dir  string `json:"dir"`  // Directory name, e.g. "02_areas"
+	Path string `json:"path"`
+}
+
+// projectDir is a project found in one of the containers
+type projectDir struct {
+	Name      string
+	Container string
+	Path      string
+}
+
+// ListContainers returns the brain's containers, 01_active first
+func ListContainers(brainPath string) ([]Container, error) {
+	containers := []Container{{Name: "active", Dir: ActiveDirName, Path: filepath.Join(brainPath, ActiveDirName)}}
+
+	entries, err := os.ReadDir(brainPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read brain directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || name == ActiveDirName || name == ArchiveDirName || name == LegacyArchiveDirName {
+			continue
+		}
+		m := containerPattern.FindStringSubmatch(name)
+		if m == nil || m[1] == "00" {
+			continue
+		}
+		containers = append(containers, Container{Name: m[2], Dir: name, Path: filepath.Join(brainPath, name)})
+	}
+
+	sort.SliceStable(containers[1:], func(i, j int) bool {
+		return containers[i+1].Dir < containers[j+1].Dir
+	})
+
+	return containers, nil
+}
+
+// FindContainer finds a container by short name ("areas") or directory name ("02_areas")
+func FindContainer(brainPath, name string) (*Container, error) {
+	containers, err := ListContainers(brainPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, container := range containers {
+		if container.Name == name || container.Dir == name {
+			return &container, nil
+		}
+		names = append(names, container.Name)
+	}
+
+	return nil, fmt.Errorf("container '%s' not found. Available: %s", name, strings.Join(names, ", "))
+}
+
+// AddContainer creates a container directory with the next free number, e.g. 03_resources
+func AddContainer(brainPath, name string) (*Container, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !validContainerName.MatchString(name) {
+		return nil, fmt.Errorf("container name can only contain lowercase letters, numbers and hyphens")
+	}
+	if name == "active" || name == "archive" || name == "dump" {
+		return nil, fmt.Errorf("'%s' is a reserved name", name)
+	}
+
+	entries, err := os.ReadDir(brainPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read brain directory: %w", err)
+	}
+
+	// Number after the highest used prefix, never reaching the archive's 99
+	next := 2
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		m := containerPattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			continue
+		}
+		if m[2] == name && entry.Name() != ArchiveDirName && entry.Name() != LegacyArchiveDirName {
+			return nil, fmt.Errorf("container '%s' already exists (%s)", name, entry.Name())
+		}
+		if n, _ := strconv.Atoi(m[1]); n < 99 && n >= next {
+			next = n + 1
+		}
+	}
+	if next >= 99 {
+		return nil, fmt.Errorf("no free container number left")
+	}
+
+	dir := fmt.Sprintf("%02d_%s", next, name)
+	path := filepath.Join(brainPath, dir)
+	if err := fileutil.EnsureDir(path); err != nil {
+		return nil, fmt.Errorf("failed to create container: %w", err)
+	}
+
+	return &Container{Name: name, Dir: dir, Path: path}, nil
+}
+
+// containerDirs returns activeDir and the other containers of the brain it belongs to
+func containerDirs(activeDir string) ([]Container, error) {
+	if _, err := os.Stat(activeDir); err != nil {
+		return nil, fmt.Errorf("failed to read active directory: %w", err)
+	}
+
+	containers, err := ListContainers(filepath.Dir(activeDir))
+	if err != nil {
+		return nil, err
+	}
+
+	// activeDir may be named differently from 01_active (e.g. in tests)
+	containers[0].Path = activeDir
+	return containers, nil
+}
+
+// listProjectDirs returns every project in every container, in container order
+func listProjectDirs(activeDir string) ([]projectDir, error) {
+	containers, err := containerDirs(activeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []projectDir
+	for i, container := range containers {
+		entries, err := os.ReadDir(container.Path)
+		if err != nil {
+			if i == 0 {
+				return nil, fmt.Errorf("failed to read active directory: %w", err)
+			}
+			continue
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			projects = append(projects, projectDir{
+				Name:      entry.Name(),
+				Container: container.Name,
+				Path:      filepath.Join(container.Path, entry.Name()),
+			})
+		}
+	}
+
+	return projects, nil
+}
+
+// ProjectNames returns the names of all projects in every container
+func ProjectNames(activeDir string) ([]string, error) {
+	dirs, err := listProjectDirs(activeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(dirs))
+	for i, dir := range dirs {
+		names[i] = dir.Name
+	}
+	return names, nil
+}
+
+// FindProjectDir returns the directory of a project in any container
+func FindProjectDir(activeDir, name string) (string, error) {
+	projects, err := listProjectDirs(activeDir)
+	if err != nil {
+		return "", err
+	}
+
+	for _, project := range projects {
+		if project.Name == name {
+			return project.Path, nil
+		}
+	}
+
+	return "", fmt.Errorf("project '%s' not found", name)
+}
+
+// ProjectContainer returns the short name of the container a project directory is in
+func ProjectContainer(projectDir string) string {
+	dir := filepath.Base(filepath.Dir(projectDir))
+	if m := containerPattern.FindStringSubmatch(dir); m != nil {
+		return m[2]
+	}
+	return "active"
+}
+
+// MoveProject moves a project into another container of the same brain
+// Returns the new project directory.
+func MoveProject(activeDir, name, containerName string) (string, error) {
+	source, err := FindProjectDir(activeDir, name)
+	if err != nil {
+		return "", err
+	}
+
+	containers, err := containerDirs(activeDir)
+	if err != nil {
+		return "", err
+	}
+
+	var target *Container
+	for i := range containers {
+		if containers[i].Name == containerName || containers[i].Dir == containerName {
+			target = &containers[i]
+			break
+		}
+	}
+	if target == nil {
+		return "", fmt.Errorf("container '%s' not found", containerName)
+	}
+
+	if filepath.Dir(source) == target.Path {
+		return "", fmt.Errorf("project '%s' is already in %s", name, target.Dir)
+	}
+
+	if err := fileutil.EnsureDir(target.Path); err != nil {
+		return "", fmt.Errorf("failed to create container: %w", err)
+	}
+
+	dest := filepath.Join(target.Path, name)
+	if fileutil.FileExists(dest) {
+		return "", fmt.Errorf("'%s' already exists in %s", name, target.Dir)
+	}
+	if err := os.Rename(source, dest); err != nil {
+		return "", fmt.Errorf("failed to move project: %w", err)
+	}
+
+	return dest, nil
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestAddAndListContainers(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	areas, err := AddContainer(tb.BrainPath, "areas")
+	if err != nil {
+		t.Fatalf("AddContainer failed: %v", err)
+	}
+	if areas.Dir != "02_areas" {
+		t.Errorf("Expected 02_areas, got %s", areas.Dir)
+	}
+
+	resources, err := AddContainer(tb.BrainPath, "resources")
+	if err != nil {
+		t.Fatalf("AddContainer failed: %v", err)
+	}
+	if resources.Dir != "03_resources" {
+		t.Errorf("Expected 03_resources, got %s", resources.Dir)
+	}
+
+	if _, err := AddContainer(tb.BrainPath, "areas"); err == nil {
+		t.Error("Expected error for duplicate container")
+	}
+	if _, err := AddContainer(tb.BrainPath, "archive"); err == nil {
+		t.Error("Expected error for reserved name")
+	}
+	if _, err := AddContainer(tb.BrainPath, "Bad Name"); err == nil {
+		t.Error("Expected error for invalid name")
+	}
+
+	// The archive and non-container directories are not containers
+	os.MkdirAll(filepath.Join(tb.BrainPath, ArchiveDirName), 0755)
+	os.MkdirAll(filepath.Join(tb.BrainPath, "templates"), 0755)
+
+	containers, err := ListContainers(tb.BrainPath)
+	if err != nil {
+		t.Fatalf("ListContainers failed: %v", err)
+	}
+
+	var dirs []string
+	for _, container := range containers {
+		dirs = append(dirs, container.Dir)
+	}
+	want := []string{ActiveDirName, "02_areas", "03_resources"}
+	if len(dirs) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, dirs)
+	}
+	for i := range want {
+		if dirs[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, dirs)
+			break
+		}
+	}
+
+	container, err := FindContainer(tb.BrainPath, "02_areas")
+	if err != nil || container.Name != "areas" {
+		t.Errorf("FindContainer by dir failed: %v", err)
+	}
+	if _, err := FindContainer(tb.BrainPath, "missing"); err == nil {
+		t.Error("Expected error for unknown container")
+	}
+}
+
+func TestProjectsAcrossContainers(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	webDir := tb.AddProject("web")
+	tb.WriteFile(filepath.Join(webDir, "todo.md"), "- [ ] Ship site\n")
+
+	healthDir := filepath.Join(tb.BrainPath, "02_areas", "health")
+	tb.WriteFile(filepath.Join(healthDir, "todo.md"), "- [ ] Book checkup\n")
+
+	dir, err := FindProjectDir(tb.ActiveDirPath, "health")
+	if err != nil {
+		t.Fatalf("FindProjectDir failed: %v", err)
+	}
+	if dir != healthDir {
+		t.Errorf("Expected %s, got %s", healthDir, dir)
+	}
+	if _, err := FindProjectDir(tb.ActiveDirPath, "missing"); err == nil {
+		t.Error("Expected error for unknown project")
+	}
+
+	projects, err := ListProjects(tb.ActiveDirPath, "")
+	if err != nil {
+		t.Fatalf("ListProjects failed: %v", err)
+	}
+	containers := map[string]string{}
+	for _, project := range projects {
+		containers[project.Name] = project.Container
+	}
+	if containers["web"] != "active" || containers["health"] != "areas" {
+		t.Errorf("Unexpected containers: %v", containers)
+	}
+
+	todos, err := ParseAllTodos(tb.ActiveDirPath, false)
+	if err != nil {
+		t.Fatalf("ParseAllTodos failed: %v", err)
+	}
+	found := false
+	for _, todo := range todos {
+		if todo.Project == "health" && todo.Content == "Book checkup" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected task from 02_areas/health, got %+v", todos)
+	}
+}
+
+func TestMoveProject(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	tb.AddProject("health")
+	if _, err := AddContainer(tb.BrainPath, "areas"); err != nil {
+		t.Fatalf("AddContainer failed: %v", err)
+	}
+
+	dest, err := MoveProject(tb.ActiveDirPath, "health", "areas")
+	if err != nil {
+		t.Fatalf("MoveProject failed: %v", err)
+	}
+	if dest != filepath.Join(tb.BrainPath, "02_areas", "health") || !tb.FileExists(dest) {
+		t.Errorf("Project not moved to 02_areas: %s", dest)
+	}
+	if ProjectContainer(dest) != "areas" {
+		t.Errorf("Expected container areas, got %s", ProjectContainer(dest))
+	}
+
+	if _, err := MoveProject(tb.ActiveDirPath, "health", "areas"); err == nil {
+		t.Error("Expected error when project is already in the container")
+	}
+	if _, err := MoveProject(tb.ActiveDirPath, "health", "missing"); err == nil {
+		t.Error("Expected error for unknown container")
+	}
+
+	// Moving back to active
+	if _, err := MoveProject(tb.ActiveDirPath, "health", "active"); err != nil {
+		t.Fatalf("MoveProject back failed: %v", err)
+	}
+	if !tb.FileExists(filepath.Join(tb.ActiveDirPath, "health")) {
+		t.Error("Project not moved back to active")
+	}
+}
@@ -2,8 +2,12 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"sort"
+	"strings"
 
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
 	"github.com/sandermoonemans/local-brain/pkg/markdown"
 )
 
@@ -14,10 +18,20 @@ type DumpItemJSON struct {
 	Content   string `json:"content"`
 	Type      string `json:"type"`
 	Timestamp string `json:"timestamp"`
+	URL       string `json:"url,omitempty"` // Only set for link items
 	StartLine int    `json:"start_line"`
 	EndLine   int    `json:"end_line"`
 }
 
+// DumpItemID returns the stable ID for a dump item
+// Notes are identified by their title, all single-line items by their full line
+func DumpItemID(item markdown.DumpItem, mtime int64) string {
+	if item.Type == markdown.ItemTypeNote {
+		return GenerateNoteID(item.StartLine, item.RawLine, mtime)
+	}
+	return GenerateTaskID(item.StartLine, item.RawLine, mtime)
+}
+
 // ParseDumpToJSON parses a dump file and returns JSON array of items
 // This replicates the combination of parse_dump_items + dump_to_json from brain-api.sh
 func ParseDumpToJSON(filePath string) ([]DumpItemJSON, error) {
@@ -41,21 +55,18 @@ func ParseDumpToJSON(filePath string) ([]DumpItemJSON, error) {
 		// Extract timestamp from content
 		cleanContent, timestamp := markdown.ExtractTimestamp(item.Content)
 
-		// Generate ID based on item type
-		var id string
-		if item.Type == markdown.ItemTypeTodo {
-			// For tasks, use full line content for ID (including "- [ ] ")
-			id = GenerateTaskID(item.StartLine, item.RawLine, mtime)
-		} else {
-			// For notes, use start line and title
-			id = GenerateNoteID(item.StartLine, item.RawLine, mtime)
+		// Links are shown by title, with the URL in its own field
+		var url string
+		if item.Type == markdown.ItemTypeLink {
+			url, cleanContent = markdown.ParseLink(cleanContent)
 		}
 
 		jsonItems = append(jsonItems, DumpItemJSON{
-			ID:        id,
+			ID:        DumpItemID(item, mtime),
 			Content:   cleanContent,
 			Type:      string(item.Type),
 			Timestamp: timestamp,
+			URL:       url,
 			StartLine: item.StartLine,
 			EndLine:   item.EndLine,
 		})
@@ -83,3 +94,86 @@ func ParseDumpToJSONString(filePath string) (string, error) {
 
 	return string(bytes), nil
 }
+
+// VerifyDumpItems checks that each item still occupies its recorded lines in the dump
+// Returns an error describing the first item whose content no longer matches
+func VerifyDumpItems(dumpPath string, items []markdown.DumpItem) error {
+	content, err := os.ReadFile(dumpPath)
+	if err != nil {
+		return err
+	}
+
+	return verifyDumpLines(strings.Split(string(content), "\n"), items)
+}
+
+// RemoveDumpItems removes several items from the dump in a single write
+// All items are verified against the current file first, so nothing is removed
+// if any item has moved or changed since the dump was parsed.
+// The caller is responsible for holding the dump lock.
+func RemoveDumpItems(dumpPath string, items []markdown.DumpItem) error {
+	content, err := os.ReadFile(dumpPath)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	if err := verifyDumpLines(lines, items); err != nil {
+		return err
+	}
+
+	// Collect line ranges to drop (1-indexed, inclusive)
+	ranges := make([][2]int, 0, len(items))
+	for _, item := range items {
+		ranges = append(ranges, [2]int{item.StartLine, item.EndLine})
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+
+	var newLines []string
+	r := 0
+	for i, line := range lines {
+		lineNum := i + 1
+		for r < len(ranges) && lineNum > ranges[r][1] {
+			r++
+		}
+		if r < len(ranges) && lineNum >= ranges[r][0] && lineNum <= ranges[r][1] {
+			continue
+		}
+		newLines = append(newLines, line)
+	}
+
+	return fileutil.AtomicWriteFile(dumpPath, []byte(strings.Join(newLines, "\n")))
+}
+
+// verifyDumpLines checks item headers and note bodies against the dump lines
+func verifyDumpLines(lines []string, items []markdown.DumpItem) error {
+	for _, item := range items {
+		if item.StartLine < 1 || item.EndLine < item.StartLine || item.EndLine > len(lines) {
+			return fmt.Errorf("item at lines %d-%d is out of range (dump has changed)", item.StartLine, item.EndLine)
+		}
+
+		expected := item.RawLine
+		if item.Type == markdown.ItemTypeNote {
+			expected = "[Note] " + item.RawLine
+		}
+
+		if lines[item.StartLine-1] != expected {
+			return fmt.Errorf("dump has changed: line %d no longer contains '%s'", item.StartLine, expected)
+		}
+
+		if item.Type != markdown.ItemTypeNote {
+			continue
+		}
+
+		// Note body must still end exactly at EndLine
+		for lineNum := item.StartLine + 1; lineNum <= item.EndLine; lineNum++ {
+			if !strings.HasPrefix(lines[lineNum-1], "    ") {
+				return fmt.Errorf("dump has changed: note '%s' no longer spans lines %d-%d", item.RawLine, item.StartLine, item.EndLine)
+			}
+		}
+		if item.EndLine < len(lines) && strings.HasPrefix(lines[item.EndLine], "    ") {
+			return fmt.Errorf("dump has changed: note '%s' no longer spans lines %d-%d", item.RawLine, item.StartLine, item.EndLine)
+		}
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/sandermoonemans/local-brain/pkg/markdown"
 	"github.com/sandermoonemans/local-brain/pkg/testutil"
 )
 
@@ -155,3 +156,112 @@ func TestParseDumpToJSONString_EmptyFile(t *testing.T) {
 		}
 	}
 }
+
+func TestParseDumpToJSON_LineItems(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	tb.AddToDump("[Link] https://go.dev/blog The Go Blog #captured:2024-01-01")
+	tb.AddToDump("[Idea] Offline mode #captured:2024-01-02")
+
+	items, err := ParseDumpToJSON(tb.DumpPath)
+	if err != nil {
+		t.Fatalf("ParseDumpToJSON failed: %v", err)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(items))
+	}
+
+	if items[0].Type != "link" || items[0].Content != "The Go Blog" || items[0].URL != "https://go.dev/blog" {
+		t.Errorf("Unexpected link item: %+v", items[0])
+	}
+	if items[0].Timestamp != "2024-01-01" {
+		t.Errorf("Expected timestamp '2024-01-01', got '%s'", items[0].Timestamp)
+	}
+
+	if items[1].Type != "idea" || items[1].Content != "Offline mode" || items[1].URL != "" {
+		t.Errorf("Unexpected idea item: %+v", items[1])
+	}
+}
+
+func TestRemoveDumpItems(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	tb.AddTaskToDump("Task 1", "2024-01-01")
+	tb.AddNoteToDump("Note 1", []string{"Line 1", "Line 2"}, "2024-01-02")
+	tb.AddTaskToDump("Task 2", "2024-01-03")
+	tb.AddTaskToDump("Task 3", "2024-01-04")
+
+	items, err := markdown.ParseDumpFile(tb.DumpPath)
+	if err != nil {
+		t.Fatalf("ParseDumpFile failed: %v", err)
+	}
+	if len(items) != 4 {
+		t.Fatalf("Expected 4 items, got %d", len(items))
+	}
+
+	// Remove several items in one call; later line numbers must not shift
+	if err := RemoveDumpItems(tb.DumpPath, []markdown.DumpItem{items[3], items[0], items[1]}); err != nil {
+		t.Fatalf("RemoveDumpItems failed: %v", err)
+	}
+
+	content := tb.ReadDumpFile()
+	if !strings.Contains(content, "Task 2") {
+		t.Errorf("Task 2 should remain in dump:\n%s", content)
+	}
+	for _, removed := range []string{"Task 1", "Note 1", "Line 1", "Line 2", "Task 3"} {
+		if strings.Contains(content, removed) {
+			t.Errorf("'%s' should have been removed from dump:\n%s", removed, content)
+		}
+	}
+}
+
+func TestRemoveDumpItems_VerifiesContent(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	tb.AddTaskToDump("Task 1", "2024-01-01")
+	tb.AddTaskToDump("Task 2", "2024-01-02")
+
+	items, err := markdown.ParseDumpFile(tb.DumpPath)
+	if err != nil {
+		t.Fatalf("ParseDumpFile failed: %v", err)
+	}
+
+	// Simulate a concurrent edit that shifts all lines down
+	original := tb.ReadDumpFile()
+	tb.WriteFile(tb.DumpPath, strings.Replace(original, "# Dump\n", "# Dump\n- [ ] Inserted\n", 1))
+
+	if err := VerifyDumpItems(tb.DumpPath, items); err == nil {
+		t.Error("Expected verification error after dump changed")
+	}
+
+	if err := RemoveDumpItems(tb.DumpPath, items[:1]); err == nil {
+		t.Fatal("Expected RemoveDumpItems to fail after dump changed")
+	}
+
+	content := tb.ReadDumpFile()
+	for _, kept := range []string{"Inserted", "Task 1", "Task 2"} {
+		if !strings.Contains(content, kept) {
+			t.Errorf("'%s' should not have been removed:\n%s", kept, content)
+		}
+	}
+}
+
+func TestVerifyDumpItems_NoteGrew(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	tb.AddNoteToDump("Note 1", []string{"Line 1"}, "2024-01-01")
+
+	items, err := markdown.ParseDumpFile(tb.DumpPath)
+	if err != nil {
+		t.Fatalf("ParseDumpFile failed: %v", err)
+	}
+
+	// Another line appended to the note body after parsing
+	original := tb.ReadDumpFile()
+	tb.WriteFile(tb.DumpPath, strings.Replace(original, "    Line 1\n", "    Line 1\n    Line 2\n", 1))
+
+	if err := VerifyDumpItems(tb.DumpPath, items); err == nil {
+		t.Error("Expected verification error when note body changed")
+	}
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+	"github.com/sandermoonemans/local-brain/pkg/markdown"
+)
+
+// JournalDirName is the brain directory holding daily journal notes
+const JournalDirName = "journal"
+
+// journalLogHeading is the section that quick entries are appended to
+const journalLogHeading = "## Log"
+
+// JournalPath returns the journal note for a date: <brain>/journal/YYYY-MM-DD.md
+func JournalPath(brainPath, date string) string {
+	return filepath.Join(brainPath, JournalDirName, date+".md")
+}
+
+// JournalContent renders a new journal note for a date
+// Lists open tasks due on or before the date, tasks in progress and tasks completed that day.
+// Tasks are written as plain bullets so they are not picked up as tasks themselves.
+func JournalContent(date string, todos []TodoItem) (string, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "", fmt.Errorf("invalid date: %s", date)
+	}
+
+	var due, inProgress, completed []TodoItem
+	for _, todo := range todos {
+		switch {
+		case todo.Status == "done":
+			if todo.DoneDate == date {
+				completed = append(completed, todo)
+			}
+		case todo.Status == "in-progress":
+			inProgress = append(inProgress, todo)
+		case todo.DueDate != "" && todo.DueDate <= date:
+			due = append(due, todo)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].DueDate < due[j].DueDate })
+
+	fm := markdown.FrontMatter{
+		Title:   "Journal " + date,
+		Created: date,
+		Tags:    []string{"journal"},
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fm.String())
+	fmt.Fprintf(&sb, "\n# %s\n", day.Format("Monday, 2 January 2006"))
+
+	writeJournalTasks(&sb, "## Due", due, func(todo TodoItem) string {
+		if todo.DueDate < date {
+			return fmt.Sprintf(" [OVERDUE: %s]", todo.DueDate)
+		}
+		return ""
+	})
+	writeJournalTasks(&sb, "## In Progress", inProgress, nil)
+	writeJournalTasks(&sb, "## Completed", completed, nil)
+
+	sb.WriteString("\n" + journalLogHeading + "\n")
+	return sb.String(), nil
+}
+
+func writeJournalTasks(sb *strings.Builder, heading string, todos []TodoItem, suffix func(TodoItem) string) {
+	fmt.Fprintf(sb, "\n%s\n\n", heading)
+	if len(todos) == 0 {
+		sb.WriteString("- (none)\n")
+		return
+	}
+	for _, todo := range todos {
+		line := fmt.Sprintf("- %s (%s)", todo.Content, todo.Project)
+		if suffix != nil {
+			line += suffix(todo)
+		}
+		sb.WriteString(line + "\n")
+	}
+}
+
+// EnsureJournal creates the journal note for a date if it does not exist yet
+// Returns the note path and whether it was created
+func EnsureJournal(brainPath, date string) (string, bool, error) {
+	path := JournalPath(brainPath, date)
+	if fileutil.FileExists(path) {
+		return path, false, nil
+	}
+
+	todos, err := ParseAllTodos(filepath.Join(brainPath, ActiveDirName), true)
+	if err != nil {
+		return "", false, err
+	}
+
+	content, err := JournalContent(date, todos)
+	if err != nil {
+		return "", false, err
+	}
+
+	if err := fileutil.EnsureDir(filepath.Dir(path)); err != nil {
+		return "", false, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	if err := fileutil.WithLock(path, func() error {
+		if fileutil.FileExists(path) {
+			return nil
+		}
+		return fileutil.AtomicWriteFile(path, []byte(content))
+	}); err != nil {
+		return "", false, fmt.Errorf("failed to create journal: %w", err)
+	}
+
+	return path, true, nil
+}
+
+// AppendJournalEntry adds a "- HH:MM text" entry at the end of the journal's Log section
+func AppendJournalEntry(path, clock, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return fmt.Errorf("journal entry cannot be empty")
+	}
+	entry := fmt.Sprintf("- %s %s", clock, text)
+
+	return fileutil.WithLock(path, func() error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+
+		logStart := -1
+		for i, line := range lines {
+			if strings.TrimSpace(line) == journalLogHeading {
+				logStart = i
+				break
+			}
+		}
+
+		if logStart < 0 {
+			lines = append(lines, "", journalLogHeading, "", entry)
+		} else {
+			// Insert after the last non-empty line of the Log section
+			insertAt := logStart + 1
+			for i := logStart + 1; i < len(lines); i++ {
+				if strings.HasPrefix(lines[i], "## ") {
+					break
+				}
+				if strings.TrimSpace(lines[i]) != "" {
+					insertAt = i + 1
+				}
+			}
+			if insertAt == logStart+1 {
+				// Empty section: keep a blank line under the heading
+				lines = insertLines(lines, insertAt, "", entry)
+			} else {
+				lines = insertLines(lines, insertAt, entry)
+			}
+		}
+
+		return fileutil.AtomicWriteFile(path, []byte(strings.Join(lines, "\n")+"\n"))
+	})
+}
+
+func insertLines(lines []string, at int, newLines ...string) []string {
+	result := make([]string, 0, len(lines)+len(newLines))
+	result = append(result, lines[:at]...)
+	result = append(result, newLines...)
+	return append(result, lines[at:]...)
+}
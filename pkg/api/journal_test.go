@@ -0,0 +1,108 @@
+package api
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestJournalContent(t *testing.T) {
+	todos := []TodoItem{
+		{Content: "Ship it", Project: "web", Status: "open", DueDate: "2024-03-04"},
+		{Content: "Old", Project: "web", Status: "open", DueDate: "2024-03-01"},
+		{Content: "Later", Project: "web", Status: "open", DueDate: "2024-03-10"},
+		{Content: "Working", Project: "api", Status: "in-progress"},
+		{Content: "Finished", Project: "api", Status: "done", DoneDate: "2024-03-04"},
+		{Content: "Finished before", Project: "api", Status: "done", DoneDate: "2024-03-01"},
+	}
+
+	content, err := JournalContent("2024-03-04", todos)
+	if err != nil {
+		t.Fatalf("JournalContent failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"title: Journal 2024-03-04",
+		"# Monday, 4 March 2024",
+		"## Due\n\n- Old (web) [OVERDUE: 2024-03-01]\n- Ship it (web)\n",
+		"## In Progress\n\n- Working (api)\n",
+		"## Completed\n\n- Finished (api)\n",
+		"## Log\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected journal to contain %q, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "Later") || strings.Contains(content, "Finished before") {
+		t.Errorf("Journal contains tasks from other days:\n%s", content)
+	}
+
+	if _, err := JournalContent("not-a-date", nil); err == nil {
+		t.Error("Expected error for invalid date")
+	}
+}
+
+func TestEnsureJournal(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	path, created, err := EnsureJournal(tb.BrainPath, "2024-03-04")
+	if err != nil {
+		t.Fatalf("EnsureJournal failed: %v", err)
+	}
+	if !created || !tb.FileExists(path) {
+		t.Fatalf("Expected journal to be created at %s", path)
+	}
+
+	tb.WriteFile(path, "custom\n")
+	if _, created, err := EnsureJournal(tb.BrainPath, "2024-03-04"); err != nil || created {
+		t.Errorf("Expected existing journal to be kept (created=%v, err=%v)", created, err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "custom\n" {
+		t.Errorf("Existing journal was overwritten: %q", data)
+	}
+}
+
+func TestAppendJournalEntry(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	path, _, err := EnsureJournal(tb.BrainPath, "2024-03-04")
+	if err != nil {
+		t.Fatalf("EnsureJournal failed: %v", err)
+	}
+
+	if err := AppendJournalEntry(path, "09:15", "Standup"); err != nil {
+		t.Fatalf("AppendJournalEntry failed: %v", err)
+	}
+	if err := AppendJournalEntry(path, "14:00", "Deployed"); err != nil {
+		t.Fatalf("AppendJournalEntry failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.HasSuffix(string(data), "## Log\n\n- 09:15 Standup\n- 14:00 Deployed\n") {
+		t.Errorf("Unexpected journal content:\n%s", data)
+	}
+
+	if err := AppendJournalEntry(path, "15:00", "  "); err == nil {
+		t.Error("Expected error for empty entry")
+	}
+}
+
+func TestAppendJournalEntry_LogInMiddle(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	path := JournalPath(tb.BrainPath, "2024-03-04")
+	tb.WriteFile(path, "# Day\n\n## Log\n\n- 09:00 First\n\n## Reflection\n\nGood day\n")
+
+	if err := AppendJournalEntry(path, "10:00", "Second"); err != nil {
+		t.Fatalf("AppendJournalEntry failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	want := "# Day\n\n## Log\n\n- 09:00 First\n- 10:00 Second\n\n## Reflection\n\nGood day\n"
+	if string(data) != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, data)
+	}
+}
@@ -1,7 +1,6 @@
 package api
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,18 +8,28 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+	"github.com/sandermoonemans/local-brain/pkg/markdown"
 )
 
 // NoteFile represents a note file in a project
+// Metadata comes from the note's front matter, or for legacy notes from the
+// "# Title" heading and "Created:" line
 type NoteFile struct {
 	Filename string    `json:"filename"`
 	Path     string    `json:"path"`
 	Title    string    `json:"title"`
 	Created  string    `json:"created"`
+	Updated  string    `json:"updated,omitempty"`
+	Tags     []string  `json:"tags"`
+	Aliases  []string  `json:"aliases"`
 	Project  string    `json:"project"`
 	ModTime  time.Time `json:"-"`
 }
 
+var createdLinePattern = regexp.MustCompile(`Created:\s*(\d{4}-\d{2}-\d{2})`)
+
 // ListNotes returns all notes in a project's notes directory
 func ListNotes(projectDir string) ([]NoteFile, error) {
 	notesDir := filepath.Join(projectDir, "notes")
@@ -57,57 +66,157 @@ func ListNotes(projectDir string) ([]NoteFile, error) {
 	return notes, nil
 }
 
-func parseNoteFile(filePath, projectName string) (NoteFile, error) {
-	filename := filepath.Base(filePath)
+// HasTag reports whether the note carries a tag (case-insensitive)
+func (n NoteFile) HasTag(tag string) bool {
+	tag = strings.TrimPrefix(tag, "#")
+	for _, t := range n.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
 
+func parseNoteFile(filePath, projectName string) (NoteFile, error) {
 	// Get file info for modification time
 	fileInfo, err := os.Stat(filePath)
 	if err != nil {
 		return NoteFile{}, err
 	}
 
-	// Read first few lines to get title and created date
-	file, err := os.Open(filePath)
+	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return NoteFile{}, err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
+	fm, body, _ := markdown.ParseFrontMatter(string(data))
 
-	// Read title (first line, should be "# Title")
-	title := ""
-	if scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, "# ") {
-			title = strings.TrimPrefix(line, "# ")
-		} else {
-			title = line
+	note := NoteFile{
+		Filename: filepath.Base(filePath),
+		Path:     filePath,
+		Title:    fm.Title,
+		Created:  fm.Created,
+		Updated:  fm.Updated,
+		Tags:     fm.Tags,
+		Aliases:  fm.Aliases,
+		Project:  projectName,
+		ModTime:  fileInfo.ModTime(),
+	}
+
+	// Legacy notes: title from the first line ("# Title"), date from "Created:"
+	if note.Title == "" {
+		firstLine, _, _ := strings.Cut(body, "\n")
+		note.Title = strings.TrimPrefix(firstLine, "# ")
+	}
+	if note.Created == "" {
+		if matches := createdLinePattern.FindStringSubmatch(body); matches != nil {
+			note.Created = matches[1]
 		}
 	}
 
-	// Read created date (look for "Created: YYYY-MM-DD")
-	created := ""
-	createdPattern := regexp.MustCompile(`Created:\s*(\d{4}-\d{2}-\d{2})`)
+	if note.Tags == nil {
+		note.Tags = []string{}
+	}
+	if note.Aliases == nil {
+		note.Aliases = []string{}
+	}
 
-	for scanner.Scan() && created == "" {
-		line := scanner.Text()
-		if matches := createdPattern.FindStringSubmatch(line); matches != nil {
-			created = matches[1]
-		}
+	return note, nil
+}
+
+// NewNoteContent renders a new note with front matter and a title heading
+func NewNoteContent(title, created string, tags []string, body string) string {
+	fm := markdown.FrontMatter{
+		Title:   title,
+		Created: created,
+		Updated: created,
+		Tags:    tags,
 	}
 
-	return NoteFile{
-		Filename: filename,
-		Path:     filePath,
-		Title:    title,
-		Created:  created,
-		Project:  projectName,
-		ModTime:  fileInfo.ModTime(),
-	}, nil
+	content := fmt.Sprintf("%s\n# %s\n", fm.String(), title)
+	if body = strings.TrimRight(body, "\n"); body != "" {
+		content += "\n" + body + "\n"
+	}
+	return content
 }
 
 // DeleteNote removes a note file
 func DeleteNote(notePath string) error {
 	return os.Remove(notePath)
 }
+
+// ResolveNoteTarget finds an existing note file in a project
+// The name may be "notes.md", a file in notes/ ("2024-01-15-budget.md" or
+// "notes/2024-01-15-budget.md"), with or without the .md extension.
+// The returned path is relative to the project directory.
+func ResolveNoteTarget(projectDir, name string) (string, error) {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "notes/")
+	if name == "" || strings.Contains(name, "/") || strings.Contains(name, "..") {
+		return "", fmt.Errorf("invalid note file '%s'", name)
+	}
+
+	if !strings.HasSuffix(name, ".md") {
+		name += ".md"
+	}
+
+	candidates := []string{filepath.Join("notes", name)}
+	if name == "notes.md" {
+		candidates = []string{"notes.md"}
+	}
+
+	for _, rel := range candidates {
+		if fileutil.FileExists(filepath.Join(projectDir, rel)) {
+			return rel, nil
+		}
+	}
+
+	return "", fmt.Errorf("note file '%s' not found in project '%s'", name, filepath.Base(projectDir))
+}
+
+// AppendNoteSection appends content to an existing note under a dated heading
+//
+//	## 2024-01-15: Title
+//
+//	content
+func AppendNoteSection(notePath, date, title, content string) error {
+	heading := date
+	if title != "" {
+		heading += ": " + title
+	}
+
+	block := fmt.Sprintf("## %s\n", heading)
+	if content = strings.TrimRight(content, "\n"); content != "" {
+		block += fmt.Sprintf("\n%s\n", content)
+	}
+
+	return appendToNote(notePath, block)
+}
+
+// AppendNoteLine appends a single line (such as an attachment link) to the end of a note
+func AppendNoteLine(notePath, line string) error {
+	return appendToNote(notePath, strings.TrimRight(line, "\n")+"\n")
+}
+
+// appendToNote appends a block after a blank line, keeping the front matter's updated date current
+func appendToNote(notePath, block string) error {
+	return fileutil.WithLock(notePath, func() error {
+		existing, err := os.ReadFile(notePath)
+		if err != nil {
+			return fmt.Errorf("failed to read note: %w", err)
+		}
+
+		if fm, body, found := markdown.ParseFrontMatter(string(existing)); found {
+			fm.Updated = time.Now().Format("2006-01-02")
+			existing = []byte(fm.String() + "\n" + body)
+		}
+
+		var sb strings.Builder
+		sb.Write(existing)
+		if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n" + block)
+
+		return fileutil.AtomicWriteFile(notePath, []byte(sb.String()))
+	})
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -217,3 +218,136 @@ func TestListNotes_OnlyMarkdownFiles(t *testing.T) {
 		t.Fatalf("Expected 2 notes, got %d", len(notes))
 	}
 }
+
+func TestResolveNoteTarget(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	projectDir := tb.AddProject("test-project")
+	tb.WriteFile(filepath.Join(projectDir, "notes", "2024-01-15-design.md"), "# Design\n")
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"notes.md", "notes.md"},
+		{"notes", "notes.md"},
+		{"2024-01-15-design.md", filepath.Join("notes", "2024-01-15-design.md")},
+		{"2024-01-15-design", filepath.Join("notes", "2024-01-15-design.md")},
+		{"notes/2024-01-15-design.md", filepath.Join("notes", "2024-01-15-design.md")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ResolveNoteTarget(projectDir, tt.name)
+			if err != nil {
+				t.Fatalf("ResolveNoteTarget failed: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+
+	for _, name := range []string{"missing.md", "../todo.md", ""} {
+		if _, err := ResolveNoteTarget(projectDir, name); err == nil {
+			t.Errorf("Expected error for '%s'", name)
+		}
+	}
+}
+
+func TestAppendNoteSection(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	notePath := filepath.Join(tb.ActiveDirPath, "test-project", "notes", "design.md")
+	tb.WriteFile(notePath, "# Design\n\nCreated: 2024-01-15\n\nInitial thoughts.")
+
+	if err := AppendNoteSection(notePath, "2024-02-01", "Follow-up", "More details\nSecond line\n"); err != nil {
+		t.Fatalf("AppendNoteSection failed: %v", err)
+	}
+
+	expected := "# Design\n\nCreated: 2024-01-15\n\nInitial thoughts.\n\n## 2024-02-01: Follow-up\n\nMore details\nSecond line\n"
+	if content := tb.ReadFile(notePath); content != expected {
+		t.Errorf("Unexpected note content:\n%q\nexpected:\n%q", content, expected)
+	}
+}
+
+func TestListNotes_FrontMatter(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	projectDir := filepath.Join(tb.ActiveDirPath, "test-project")
+	tb.WriteFile(filepath.Join(projectDir, "notes", "design.md"), `---
+title: Design review
+created: 2024-01-15
+updated: 2024-02-01
+tags: [architecture, api]
+aliases: [design]
+---
+
+# Design review
+
+Body.
+`)
+	tb.WriteFile(filepath.Join(projectDir, "notes", "legacy.md"), "# Legacy note\n\nCreated: 2023-12-01\n\nBody.\n")
+
+	notes, err := ListNotes(projectDir)
+	if err != nil {
+		t.Fatalf("ListNotes failed: %v", err)
+	}
+
+	byName := make(map[string]NoteFile)
+	for _, note := range notes {
+		byName[note.Filename] = note
+	}
+
+	design := byName["design.md"]
+	if design.Title != "Design review" || design.Created != "2024-01-15" || design.Updated != "2024-02-01" {
+		t.Errorf("Unexpected front matter fields: %+v", design)
+	}
+	if len(design.Tags) != 2 || !design.HasTag("API") || !design.HasTag("#architecture") {
+		t.Errorf("Unexpected tags: %v", design.Tags)
+	}
+	if len(design.Aliases) != 1 || design.Aliases[0] != "design" {
+		t.Errorf("Unexpected aliases: %v", design.Aliases)
+	}
+
+	legacy := byName["legacy.md"]
+	if legacy.Title != "Legacy note" || legacy.Created != "2023-12-01" {
+		t.Errorf("Legacy note not parsed: %+v", legacy)
+	}
+	if legacy.Tags == nil || len(legacy.Tags) != 0 {
+		t.Errorf("Expected empty tags for legacy note, got %v", legacy.Tags)
+	}
+}
+
+func TestNewNoteContent(t *testing.T) {
+	content := NewNoteContent("Standup", "2024-01-15", []string{"meeting"}, "Discussed roadmap\n")
+
+	expected := "---\ntitle: Standup\ncreated: 2024-01-15\nupdated: 2024-01-15\ntags: [meeting]\n---\n\n# Standup\n\nDiscussed roadmap\n"
+	if content != expected {
+		t.Errorf("Unexpected note content:\n%q\nexpected:\n%q", content, expected)
+	}
+}
+
+func TestAppendNoteSection_UpdatesFrontMatter(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	notePath := filepath.Join(tb.ActiveDirPath, "test-project", "notes", "standup.md")
+	tb.WriteFile(notePath, NewNoteContent("Standup", "2024-01-15", nil, "First"))
+
+	if err := AppendNoteSection(notePath, "2024-01-16", "", "Second"); err != nil {
+		t.Fatalf("AppendNoteSection failed: %v", err)
+	}
+
+	notes, err := ListNotes(filepath.Dir(filepath.Dir(notePath)))
+	if err != nil || len(notes) != 1 {
+		t.Fatalf("Expected 1 note, got %d (%v)", len(notes), err)
+	}
+
+	today := time.Now().Format("2006-01-02")
+	if notes[0].Updated != today {
+		t.Errorf("Expected updated %s, got %s", today, notes[0].Updated)
+	}
+	if content := tb.ReadFile(notePath); !strings.HasSuffix(content, "First\n\n## 2024-01-16\n\nSecond\n") {
+		t.Errorf("Unexpected content:\n%s", content)
+	}
+}
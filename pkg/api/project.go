@@ -1,8 +1,6 @@
 package api
 
 import (
-	"bufio"
-	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -13,27 +11,26 @@ import (
 type ProjectInfo struct {
 	Name      string `json:"name"`
 	Path      string `json:"path"`
+	Container string `json:"container"` // Short container name: active, areas, ...
 	Focused   bool   `json:"focused"`
 	RepoCount int    `json:"repo_count"`
-	TaskCount int    `json:"task_count"`
+	TaskCount int    `json:"task_count"`           // Tasks not done: open, in progress or blocked
+	MetaError string `json:"meta_error,omitempty"` // Why .project.json could not be read; defaults are used
+	ProjectMeta
 }
 
-// ListProjects returns all projects in the active directory
+// ListProjects returns all projects in the active directory and the brain's other containers
 func ListProjects(activeDir, focusedProject string) ([]ProjectInfo, error) {
-	entries, err := os.ReadDir(activeDir)
+	dirs, err := listProjectDirs(activeDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read active directory: %w", err)
+		return nil, err
 	}
 
 	var projects []ProjectInfo
 
-	for _, entry := range entries {
-		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
-			continue
-		}
-
-		projectName := entry.Name()
-		projectPath := filepath.Join(activeDir, projectName)
+	for _, dir := range dirs {
+		projectName := dir.Name
+		projectPath := dir.Path
 
 		// Count repos
 		repoCount := 0
@@ -48,26 +45,29 @@ func ListProjects(activeDir, focusedProject string) ([]ProjectInfo, error) {
 			}
 		}
 
-		// Count tasks
+		// Count tasks that are not done (open, in progress or blocked)
 		taskCount := 0
 		todoFile := filepath.Join(projectPath, "todo.md")
 		if data, err := os.ReadFile(todoFile); err == nil {
-			taskPattern := regexp.MustCompile(`^\s*- \[ \]`)
 			lines := strings.Split(string(data), "\n")
 			for _, line := range lines {
-				if taskPattern.MatchString(line) {
+				if todoOpenPattern.MatchString(line) || todoInProgressPattern.MatchString(line) || todoBlockedPattern.MatchString(line) {
 					taskCount++
 				}
 			}
 		}
 
-		projects = append(projects, ProjectInfo{
+		project := ProjectInfo{
 			Name:      projectName,
 			Path:      projectPath,
+			Container: dir.Container,
 			Focused:   projectName == focusedProject,
 			RepoCount: repoCount,
 			TaskCount: taskCount,
-		})
+		}
+		project.ProjectMeta, project.MetaError = loadProjectMetaOrDefault(projectPath)
+
+		projects = append(projects, project)
 	}
 
 	return projects, nil
@@ -93,69 +93,3 @@ func ExtractRepoName(gitURL string) string {
 
 	return filepath.Base(url)
 }
-
-// GetLinkedRepos returns the list of linked repository paths for a project
-func GetLinkedRepos(projectDir string) ([]string, error) {
-	reposFile := filepath.Join(projectDir, ".repos")
-
-	if _, err := os.Stat(reposFile); os.IsNotExist(err) {
-		return []string{}, nil
-	}
-
-	file, err := os.Open(reposFile)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open .repos file: %w", err)
-	}
-	defer file.Close()
-
-	devDir := filepath.Join(os.Getenv("HOME"), "dev")
-	var repos []string
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		gitURL := strings.TrimSpace(scanner.Text())
-
-		// Skip empty lines and comments
-		if gitURL == "" || strings.HasPrefix(gitURL, "#") {
-			continue
-		}
-
-		// Extract repo name and construct path
-		repoName := ExtractRepoName(gitURL)
-		if repoName != "" {
-			repos = append(repos, filepath.Join(devDir, repoName))
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failed to read .repos file: %w", err)
-	}
-
-	return repos, nil
-}
-
-// AddRepoLink adds a git URL to the project's .repos file
-func AddRepoLink(projectDir, gitURL string) error {
-	reposFile := filepath.Join(projectDir, ".repos")
-
-	// Check if already linked
-	if data, err := os.ReadFile(reposFile); err == nil {
-		lines := strings.Split(string(data), "\n")
-		for _, line := range lines {
-			if strings.TrimSpace(line) == gitURL {
-				// Already linked
-				return nil
-			}
-		}
-	}
-
-	// Append to file
-	f, err := os.OpenFile(reposFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open .repos file: %w", err)
-	}
-	defer f.Close()
-
-	_, err = fmt.Fprintln(f, gitURL)
-	return err
-}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/sandermoonemans/local-brain/pkg/testutil"
 )
@@ -51,6 +52,8 @@ func TestListProjects_WithTasks(t *testing.T) {
 
 - [ ] Task 1
 - [ ] Task 2
+- [>] In progress task
+- [-] Blocked task
 - [x] Completed task
 `
 	tb.WriteFile(todoFile, content)
@@ -64,9 +67,9 @@ func TestListProjects_WithTasks(t *testing.T) {
 		t.Fatalf("Expected 1 project, got %d", len(projects))
 	}
 
-	// Should count only open tasks
-	if projects[0].TaskCount != 2 {
-		t.Errorf("Expected 2 tasks, got %d", projects[0].TaskCount)
+	// Should count open, in-progress and blocked tasks, but not completed ones
+	if projects[0].TaskCount != 4 {
+		t.Errorf("Expected 4 tasks, got %d", projects[0].TaskCount)
 	}
 }
 
@@ -173,7 +176,7 @@ https://github.com/user/repo3.git
 `
 	tb.WriteFile(reposFile, reposContent)
 
-	repos, err := GetLinkedRepos(projectDir)
+	repos, _, err := GetLinkedRepos(projectDir, RepoOptions{})
 	if err != nil {
 		t.Fatalf("GetLinkedRepos failed: %v", err)
 	}
@@ -202,7 +205,7 @@ func TestGetLinkedRepos_NoFile(t *testing.T) {
 
 	projectDir := filepath.Join(tb.ActiveDirPath, "no-repos")
 
-	repos, err := GetLinkedRepos(projectDir)
+	repos, _, err := GetLinkedRepos(projectDir, RepoOptions{})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -220,7 +223,7 @@ func TestGetLinkedRepos_EmptyFile(t *testing.T) {
 
 	tb.WriteFile(reposFile, "\n\n\n")
 
-	repos, err := GetLinkedRepos(projectDir)
+	repos, _, err := GetLinkedRepos(projectDir, RepoOptions{})
 	if err != nil {
 		t.Fatalf("GetLinkedRepos failed: %v", err)
 	}
@@ -229,3 +232,127 @@ func TestGetLinkedRepos_EmptyFile(t *testing.T) {
 		t.Errorf("Expected 0 repos, got %d", len(repos))
 	}
 }
+
+func TestProjectMeta(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	projectDir := tb.AddProject("web")
+
+	meta, err := LoadProjectMeta(projectDir)
+	if err != nil {
+		t.Fatalf("LoadProjectMeta failed: %v", err)
+	}
+	if meta.Status != ProjectStatusActive {
+		t.Errorf("Expected default status active, got %q", meta.Status)
+	}
+
+	for key, value := range map[string]string{
+		"description": "Web frontend",
+		"status":      "On-Hold",
+		"target":      "2026-12-01",
+		"links":       "https://a.io, , https://b.io",
+	} {
+		if _, err := SetProjectMeta(projectDir, key, value); err != nil {
+			t.Fatalf("SetProjectMeta(%s) failed: %v", key, err)
+		}
+	}
+
+	projects, err := ListProjects(tb.ActiveDirPath, "")
+	if err != nil {
+		t.Fatalf("ListProjects failed: %v", err)
+	}
+	got := projects[0].ProjectMeta
+	if got.Description != "Web frontend" || got.Status != ProjectStatusOnHold || got.Target != "2026-12-01" {
+		t.Errorf("Unexpected metadata: %+v", got)
+	}
+	if len(got.Links) != 2 || got.Links[1] != "https://b.io" {
+		t.Errorf("Unexpected links: %v", got.Links)
+	}
+
+	// Empty value clears a field
+	meta, err = SetProjectMeta(projectDir, "description", "")
+	if err != nil || meta.Description != "" {
+		t.Errorf("Expected description to be cleared, got %q (%v)", meta.Description, err)
+	}
+
+	invalid := []struct{ key, value string }{
+		{"status", "done"},
+		{"target", "someday-ish"},
+		{"color", "blue"},
+	}
+	for _, tt := range invalid {
+		if _, err := SetProjectMeta(projectDir, tt.key, tt.value); err == nil {
+			t.Errorf("Expected error for %s=%s", tt.key, tt.value)
+		}
+	}
+}
+
+func TestListProjects_InvalidMeta(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	projectDir := tb.AddProject("broken")
+	tb.WriteFile(filepath.Join(projectDir, ProjectMetaFile), "{not json")
+
+	tb.AddProject("fine")
+
+	// A malformed file should not hide the project or break the listing
+	projects, err := ListProjects(tb.ActiveDirPath, "")
+	if err != nil {
+		t.Fatalf("ListProjects failed: %v", err)
+	}
+	if len(projects) != 2 {
+		t.Fatalf("Expected 2 projects, got %d", len(projects))
+	}
+	for _, project := range projects {
+		switch project.Name {
+		case "broken":
+			if project.MetaError == "" || project.Status != ProjectStatusActive {
+				t.Errorf("Expected default metadata with an error, got %+v", project)
+			}
+		case "fine":
+			if project.MetaError != "" {
+				t.Errorf("Unexpected metadata error: %s", project.MetaError)
+			}
+		}
+	}
+}
+
+func TestProjectMetaSet(t *testing.T) {
+	var meta ProjectMeta
+
+	// Status is validated case-insensitively; empty restores the default
+	if err := meta.Set("Status", "On-Hold"); err != nil || meta.Status != ProjectStatusOnHold {
+		t.Errorf("Expected on-hold, got %q (%v)", meta.Status, err)
+	}
+	if err := meta.Set("status", "finished"); err == nil || meta.Status != ProjectStatusOnHold {
+		t.Errorf("Expected error and unchanged status, got %q (%v)", meta.Status, err)
+	}
+	if err := meta.Set("status", ""); err != nil || meta.Status != ProjectStatusActive {
+		t.Errorf("Expected empty status to reset to active, got %q (%v)", meta.Status, err)
+	}
+
+	// Dates are parsed to YYYY-MM-DD; invalid dates leave the field alone
+	if err := meta.Set("start", "2026-02-15"); err != nil || meta.Start != "2026-02-15" {
+		t.Errorf("Expected start 2026-02-15, got %q (%v)", meta.Start, err)
+	}
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	if err := meta.Set("target", "tomorrow"); err != nil || meta.Target != tomorrow {
+		t.Errorf("Expected target %s, got %q (%v)", tomorrow, meta.Target, err)
+	}
+	if err := meta.Set("target", "2026-02-30"); err == nil || meta.Target != tomorrow {
+		t.Errorf("Expected error and unchanged target, got %q (%v)", meta.Target, err)
+	}
+	if err := meta.Set("start", ""); err != nil || meta.Start != "" {
+		t.Errorf("Expected start to be cleared, got %q (%v)", meta.Start, err)
+	}
+
+	// Links are a comma-separated list; empty clears them
+	if err := meta.Set("links", " https://a.io ,, https://b.io "); err != nil || len(meta.Links) != 2 || meta.Links[0] != "https://a.io" {
+		t.Errorf("Unexpected links: %v (%v)", meta.Links, err)
+	}
+	if err := meta.Set("links", ""); err != nil || meta.Links != nil {
+		t.Errorf("Expected links to be cleared, got %v (%v)", meta.Links, err)
+	}
+
+	if err := meta.Set("color", "blue"); err == nil {
+		t.Error("Expected error for unknown key")
+	}
+}
@@ -0,0 +1,536 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+)
+
+const (
+	// ProjectUndoDirName holds the originals of merged and split projects
+	ProjectUndoDirName = ".project-undo"
+
+	projectChangeManifest = "change.json"
+	maxProjectUndo        = 10 // Older undo entries are deleted
+)
+
+// ProjectChange records a merge or split so it can be undone
+type ProjectChange struct {
+	ID       string            `json:"id"` // Directory name in .project-undo
+	Op       string            `json:"op"` // "merge" or "split"
+	Summary  string            `json:"summary"`
+	Date     string            `json:"date"`
+	Tasks    int               `json:"tasks"`             // Tasks moved
+	Notes    int               `json:"notes"`             // Note files moved
+	Renamed  map[string]string `json:"renamed,omitempty"` // Note or attachment renamed to avoid a collision
+	Focus    *focusChange      `json:"focus,omitempty"`   // Set when the change moved the focused project
+	Projects []changedPath     `json:"projects"`
+	Files    []changedPath     `json:"files,omitempty"`
+}
+
+// focusChange records the focused project before and after a change
+type focusChange struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// FocusAfterUndo returns the project to focus after undoing the change
+// focused is the currently focused project. ok is false when the change did
+// not move the focus or it was moved elsewhere since.
+func (c *ProjectChange) FocusAfterUndo(focused string) (string, bool) {
+	if c.Focus == nil || focused != c.Focus.After {
+		return "", false
+	}
+	return c.Focus.Before, true
+}
+
+// fileRewrite is a pending change to a file's content
+type fileRewrite struct {
+	path     string
+	original string
+	updated  string
+	apply    func(content string) string // Recomputes updated from the content at write time
+}
+
+// changedPath is a project directory or file touched by a change
+type changedPath struct {
+	Path   string `json:"path"`
+	Backup string `json:"backup,omitempty"` // Original inside the undo directory, empty if Path was created
+	Exists bool   `json:"exists"`           // Whether Path exists after the change
+	Hash   string `json:"hash,omitempty"`   // Content after the change, to detect later edits
+}
+
+// ProjectUndoPath returns <brain>/.project-undo
+func ProjectUndoPath(brainPath string) string {
+	return filepath.Join(brainPath, ProjectUndoDirName)
+}
+
+// stagingDir returns a hidden directory next to a project for building its new content
+// Hidden directories are skipped when listing projects.
+func stagingDir(projectPath, suffix string) string {
+	dir := filepath.Dir(projectPath)
+	return filepath.Join(dir, uniqueDirName(dir, "."+filepath.Base(projectPath)+"."+suffix))
+}
+
+// applyProjectChange swaps staged project directories into place and rewrites files
+// staged maps a live project path to the directory holding its new content.
+// The originals are moved to the undo directory; on any failure everything is
+// put back and the staged directories are removed.
+func applyProjectChange(brainPath string, change *ProjectChange, staged map[string]string, rewrites []fileRewrite, now time.Time) (err error) {
+	defer func() {
+		for _, dir := range staged {
+			os.RemoveAll(dir)
+		}
+	}()
+
+	undoRoot := ProjectUndoPath(brainPath)
+	if err := fileutil.EnsureDir(undoRoot); err != nil {
+		return fmt.Errorf("failed to create undo directory: %w", err)
+	}
+	entries, err := undoEntries(undoRoot)
+	if err != nil {
+		return err
+	}
+	seq := 1
+	if len(entries) > 0 {
+		seq = undoSeq(entries[len(entries)-1]) + 1
+	}
+	change.ID = fmt.Sprintf("%04d-%s-%s", seq, now.Format("20060102-150405"), change.Op)
+	change.Date = now.Format("2006-01-02 15:04")
+	undoDir := filepath.Join(undoRoot, change.ID)
+	if err := os.MkdirAll(filepath.Join(undoDir, "files"), 0755); err != nil {
+		return fmt.Errorf("failed to create undo directory: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			revertProjectChange(undoDir, change, false)
+			os.RemoveAll(undoDir)
+		}
+	}()
+
+	livePaths := make([]string, 0, len(staged))
+	for path := range staged {
+		livePaths = append(livePaths, path)
+	}
+	sort.Strings(livePaths)
+
+	for i, path := range livePaths {
+		entry := changedPath{Path: path}
+		if fileutil.FileExists(path) {
+			entry.Backup = filepath.Join("projects", strconv.Itoa(i))
+			if err := os.MkdirAll(filepath.Join(undoDir, "projects"), 0755); err != nil {
+				return fmt.Errorf("failed to create undo directory: %w", err)
+			}
+			if err := os.Rename(path, filepath.Join(undoDir, entry.Backup)); err != nil {
+				return fmt.Errorf("failed to back up %s: %w", filepath.Base(path), err)
+			}
+		}
+		change.Projects = append(change.Projects, entry)
+
+		if dir := staged[path]; dir != "" {
+			if err := os.Rename(dir, path); err != nil {
+				return fmt.Errorf("failed to move %s into place: %w", filepath.Base(path), err)
+			}
+			hash, err := treeHash(path)
+			if err != nil {
+				return fmt.Errorf("failed to hash %s: %w", filepath.Base(path), err)
+			}
+			change.Projects[len(change.Projects)-1].Exists = true
+			change.Projects[len(change.Projects)-1].Hash = hash
+		}
+	}
+
+	for i := range rewrites {
+		rewrite := &rewrites[i]
+		backup := filepath.Join("files", strconv.Itoa(i))
+		err := writeRewrite(rewrite, func(original string) error {
+			if err := os.WriteFile(filepath.Join(undoDir, backup), []byte(original), 0644); err != nil {
+				return fmt.Errorf("failed to back up %s: %w", rewrite.path, err)
+			}
+			change.Files = append(change.Files, changedPath{Path: rewrite.path, Backup: backup, Exists: true})
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", rewrite.path, err)
+		}
+		change.Files[len(change.Files)-1].Hash = contentHash([]byte(rewrite.updated))
+	}
+
+	data, err := json.MarshalIndent(change, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal change: %w", err)
+	}
+	if err := fileutil.AtomicWriteFile(filepath.Join(undoDir, projectChangeManifest), data); err != nil {
+		return fmt.Errorf("failed to write undo record: %w", err)
+	}
+
+	pruneProjectUndo(undoRoot)
+	return nil
+}
+
+// revertProjectChange puts the originals recorded in change back in place
+// Unless force is set, files edited since the change wrote them are kept.
+func revertProjectChange(undoDir string, change *ProjectChange, force bool) error {
+	var errs []string
+
+	for _, file := range change.Files {
+		data, err := os.ReadFile(filepath.Join(undoDir, file.Backup))
+		if err == nil {
+			err = restoreFile(file.Path, data, file.Hash, force)
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	for _, project := range change.Projects {
+		if project.Exists {
+			if err := os.RemoveAll(project.Path); err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+		}
+		if project.Backup != "" {
+			if err := os.Rename(filepath.Join(undoDir, project.Backup), project.Path); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// ListProjectChanges returns the merges and splits that can be undone, newest first
+func ListProjectChanges(brainPath string) ([]ProjectChange, error) {
+	entries, err := undoEntries(ProjectUndoPath(brainPath))
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []ProjectChange
+	for i := len(entries) - 1; i >= 0; i-- {
+		data, err := os.ReadFile(filepath.Join(ProjectUndoPath(brainPath), entries[i], projectChangeManifest))
+		if err != nil {
+			continue // Incomplete change
+		}
+		var change ProjectChange
+		if err := json.Unmarshal(data, &change); err != nil {
+			return nil, fmt.Errorf("failed to parse undo record %s: %w", entries[i], err)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// UndoProjectChange reverts the most recent merge or split
+// Refuses if a project it touched was created or removed since, and, unless
+// force is set, if a project or file it wrote was edited since: undo restores
+// the snapshots taken before the change, which would drop those edits.
+func UndoProjectChange(brainPath string, force bool) (*ProjectChange, error) {
+	changes, err := ListProjectChanges(brainPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("nothing to undo")
+	}
+	change := changes[0]
+	undoDir := filepath.Join(ProjectUndoPath(brainPath), change.ID)
+
+	for _, project := range change.Projects {
+		if fileutil.FileExists(project.Path) != project.Exists {
+			return nil, fmt.Errorf("%s changed since the %s; restore it by hand from %s", project.Path, change.Op, undoDir)
+		}
+	}
+	for _, project := range change.Projects {
+		if project.Backup != "" && !fileutil.FileExists(filepath.Join(undoDir, project.Backup)) {
+			return nil, fmt.Errorf("undo record %s is incomplete", change.ID)
+		}
+	}
+
+	if !force {
+		edited, err := editedSinceChange(&change)
+		if err != nil {
+			return nil, err
+		}
+		if len(edited) > 0 {
+			return nil, fmt.Errorf("edited since the %s, undo would discard the changes to: %s (use --force to undo anyway)", change.Op, strings.Join(edited, ", "))
+		}
+	}
+
+	if err := revertProjectChange(undoDir, &change, force); err != nil {
+		return nil, fmt.Errorf("failed to undo %s: %w", change.Op, err)
+	}
+
+	if err := os.RemoveAll(undoDir); err != nil {
+		return nil, fmt.Errorf("failed to remove undo record: %w", err)
+	}
+
+	return &change, nil
+}
+
+// editedSinceChange returns the projects and files whose content differs from what the change wrote
+func editedSinceChange(change *ProjectChange) ([]string, error) {
+	var edited []string
+
+	for _, project := range change.Projects {
+		if !project.Exists || project.Hash == "" {
+			continue
+		}
+		hash, err := treeHash(project.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash %s: %w", project.Path, err)
+		}
+		if hash != project.Hash {
+			edited = append(edited, project.Path)
+		}
+	}
+
+	for _, file := range change.Files {
+		if file.Hash == "" {
+			continue
+		}
+		data, err := os.ReadFile(file.Path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", file.Path, err)
+		}
+		if err != nil || contentHash(data) != file.Hash {
+			edited = append(edited, file.Path)
+		}
+	}
+
+	return edited, nil
+}
+
+// treeHash hashes the file names and contents of a directory
+func treeHash(dir string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			fmt.Fprintf(h, "%s/\n", filepath.ToSlash(rel))
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s %s\n", filepath.ToSlash(rel), contentHash(data))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// pruneProjectUndo keeps only the newest undo entries
+func pruneProjectUndo(undoRoot string) {
+	entries, err := undoEntries(undoRoot)
+	if err != nil || len(entries) <= maxProjectUndo {
+		return
+	}
+	for _, entry := range entries[:len(entries)-maxProjectUndo] {
+		os.RemoveAll(filepath.Join(undoRoot, entry))
+	}
+}
+
+// undoEntries returns the undo directory names, oldest first
+// Names start with a sequence number so changes made within the same second keep their order.
+func undoEntries(undoRoot string) ([]string, error) {
+	entries, err := os.ReadDir(undoRoot)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read undo directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return undoSeq(names[i]) < undoSeq(names[j])
+	})
+	return names, nil
+}
+
+// undoSeq returns the sequence number an undo directory name starts with
+func undoSeq(name string) int {
+	prefix, _, _ := strings.Cut(name, "-")
+	seq, _ := strconv.Atoi(prefix)
+	return seq
+}
+
+// copyTree copies a directory recursively, keeping file modes
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
+
+// brainTextFiles returns the files that may reference projects: the dump,
+// every project's todo.md, notes.md and notes, and the journal
+// Projects in skip are left out.
+func brainTextFiles(brainPath, activeDir string, skip ...string) ([]string, error) {
+	dirs, err := listProjectDirs(activeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{filepath.Join(brainPath, "00_dump.md")}
+	for _, dir := range dirs {
+		if slices.Contains(skip, dir.Path) {
+			continue
+		}
+		files = append(files, projectSourceFiles(dir.Path)...)
+	}
+
+	journal, _ := filepath.Glob(filepath.Join(brainPath, JournalDirName, "*.md"))
+	sort.Strings(journal)
+	return append(files, journal...), nil
+}
+
+// rewriteFiles computes content changes for existing files
+func rewriteFiles(files []string, rewrite func(path, content string) string) ([]fileRewrite, error) {
+	var rewrites []fileRewrite
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		original := string(data)
+		if updated := rewrite(path, original); updated != original {
+			apply := func(content string) string { return rewrite(path, content) }
+			rewrites = append(rewrites, fileRewrite{path: path, original: original, updated: updated, apply: apply})
+		}
+	}
+	return rewrites, nil
+}
+
+// writeRewrite writes a rewrite while holding the file's lock
+// The file is re-read inside the lock and the change applied again, so content
+// added since the rewrite was computed (e.g. a capture into the dump) is kept.
+// save, if set, receives the content about to be replaced before it is written.
+func writeRewrite(rewrite *fileRewrite, save func(original string) error) error {
+	return fileutil.WithLock(rewrite.path, func() error {
+		data, err := os.ReadFile(rewrite.path)
+		if err != nil {
+			return err
+		}
+		rewrite.original = string(data)
+		rewrite.updated = rewrite.apply(rewrite.original)
+
+		if save != nil {
+			if err := save(rewrite.original); err != nil {
+				return err
+			}
+		}
+		return fileutil.AtomicWriteFile(rewrite.path, []byte(rewrite.updated))
+	})
+}
+
+// restoreFile writes a file's original content back while holding its lock
+// Unless force is set, a file whose content no longer matches hash (what the
+// change wrote) was edited since and is left alone.
+func restoreFile(path string, data []byte, hash string, force bool) error {
+	return fileutil.WithLock(path, func() error {
+		if !force && hash != "" {
+			current, err := os.ReadFile(path)
+			if err != nil || contentHash(current) != hash {
+				return fmt.Errorf("%s was edited since, not restored", path)
+			}
+		}
+		return fileutil.AtomicWriteFile(path, data)
+	})
+}
+
+// rewriteFilesInPlace applies a content change to files in a staging directory
+func rewriteFilesInPlace(files []string, rewrite func(content string) string) error {
+	changes, err := rewriteFiles(files, func(_, content string) string { return rewrite(content) })
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		if err := os.WriteFile(change.path, []byte(change.updated), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", change.path, err)
+		}
+	}
+	return nil
+}
+
+// relinkNotes rewrites [[project/note]] and [[note]] link targets
+// relink receives the project ("" for bare links) and note name and returns
+// the new target, or false to keep the link.
+func relinkNotes(content string, relink func(project, note string) (string, bool)) string {
+	return wikiLinkPattern.ReplaceAllStringFunc(content, func(match string) string {
+		parts := wikiLinkPattern.FindStringSubmatch(match)
+		target := strings.TrimSpace(parts[1])
+		project, note, ok := strings.Cut(target, "/")
+		if !ok {
+			project, note = "", target
+		}
+		newTarget, ok := relink(project, note)
+		if !ok {
+			return match
+		}
+		return "[[" + newTarget + parts[2] + parts[3] + "]]"
+	})
+}
+
+// noteStem is the lowercase file name of a note without .md, as used in links
+func noteStem(name string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), ".md"))
+}
@@ -0,0 +1,478 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+)
+
+// MergeProjects merges project src into dst and removes src
+//   - todo.md sections are appended to the section with the same heading
+//   - notes.md is appended under a "From <src>" heading
+//   - notes and attachments are moved; name collisions get a -2 suffix
+//   - .repos are unioned, dst keeps its .project.json
+//
+// Links, dump routing, refile rules and refile history pointing at src are
+// rewritten to dst. The originals are kept so the merge can be undone with
+// UndoProjectChange. focused is the currently focused project: when it is src,
+// the caller moves focus to dst and the change records it for undo.
+func MergeProjects(brainPath, src, dst, focused string, now time.Time) (*ProjectChange, error) {
+	activeDir := filepath.Join(brainPath, ActiveDirName)
+
+	srcDir, err := FindProjectDir(activeDir, src)
+	if err != nil {
+		return nil, err
+	}
+	dstDir, err := FindProjectDir(activeDir, dst)
+	if err != nil {
+		return nil, err
+	}
+	if srcDir == dstDir {
+		return nil, fmt.Errorf("cannot merge a project into itself")
+	}
+
+	change := &ProjectChange{
+		Op:      "merge",
+		Summary: fmt.Sprintf("merge %s into %s", src, dst),
+		Renamed: make(map[string]string),
+	}
+	if focused == src {
+		change.Focus = &focusChange{Before: src, After: dst}
+	}
+
+	staged := stagingDir(dstDir, "merge")
+	if err := copyTree(dstDir, staged); err != nil {
+		os.RemoveAll(staged)
+		return nil, fmt.Errorf("failed to stage %s: %w", dst, err)
+	}
+
+	renamedNotes, err := mergeProjectInto(srcDir, staged, src, dst, change)
+	if err != nil {
+		os.RemoveAll(staged)
+		return nil, err
+	}
+
+	rewrites, err := projectReferenceRewrites(brainPath, activeDir, src, dst, renamedNotes, srcDir, dstDir)
+	if err != nil {
+		os.RemoveAll(staged)
+		return nil, err
+	}
+
+	if err := applyProjectChange(brainPath, change, map[string]string{dstDir: staged, srcDir: ""}, rewrites, now); err != nil {
+		return nil, err
+	}
+
+	// The refile index caches terms by project name; it is rebuilt on the next refile
+	os.Remove(refileStatePath(brainPath, similarityIndexFile))
+
+	return change, nil
+}
+
+// mergeProjectInto copies the content of srcDir into the staged copy of dst
+// Returns the notes renamed to avoid collisions (lowercase name -> new name).
+func mergeProjectInto(srcDir, staged, src, dst string, change *ProjectChange) (map[string]string, error) {
+	// Pick names for src's notes that do not collide with dst's
+	// Names given to earlier src notes are taken too, so no two notes get the same target
+	renamedNotes := make(map[string]string)
+	noteNames := make(map[string]string)
+	taken := make(map[string]bool)
+	srcNotes, _ := filepath.Glob(filepath.Join(srcDir, "notes", "*"))
+	for _, note := range srcNotes {
+		name := filepath.Base(note)
+		target := uniqueFileName(filepath.Join(staged, "notes"), name, taken)
+		taken[target] = true
+		noteNames[note] = target
+		if target != name {
+			renamedNotes[noteStem(name)] = strings.TrimSuffix(target, ".md")
+			change.Renamed["notes/"+name] = "notes/" + target
+		}
+	}
+
+	relinkSrc := func(project, note string) (string, bool) {
+		if project != "" && !strings.EqualFold(project, src) {
+			return "", false
+		}
+		renamed, ok := renamedNotes[noteStem(note)]
+		if project == "" {
+			return renamed, ok
+		}
+		if !ok {
+			renamed = note
+		}
+		return dst + "/" + renamed, true
+	}
+
+	// dst's own bare links keep pointing at dst's notes
+	err := rewriteFilesInPlace(projectSourceFiles(staged), func(content string) string {
+		return relinkNotes(content, func(project, note string) (string, bool) {
+			if project == "" {
+				return "", false
+			}
+			return relinkSrc(project, note)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	renamedAttachments, err := mergeAttachments(filepath.Join(srcDir, AttachmentsDirName), filepath.Join(staged, AttachmentsDirName))
+	if err != nil {
+		return nil, err
+	}
+	for from, to := range renamedAttachments {
+		change.Renamed[AttachmentsDirName+"/"+from] = AttachmentsDirName + "/" + to
+	}
+
+	// Content moving from src: relink and point at renamed attachments
+	fromSrc := func(content string) string {
+		return renameAttachmentLinks(relinkNotes(content, relinkSrc), renamedAttachments)
+	}
+
+	if err := fileutil.EnsureDir(filepath.Join(staged, "notes")); err != nil {
+		return nil, fmt.Errorf("failed to create notes directory: %w", err)
+	}
+	for _, note := range srcNotes {
+		target := filepath.Join(staged, "notes", noteNames[note])
+		info, err := os.Stat(note)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", note, err)
+		}
+		if !info.Mode().IsRegular() || !strings.HasSuffix(note, ".md") {
+			if err := copyTree(note, target); err != nil {
+				return nil, fmt.Errorf("failed to copy %s: %w", note, err)
+			}
+			continue
+		}
+
+		data, err := os.ReadFile(note)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", note, err)
+		}
+		if err := os.WriteFile(target, []byte(fromSrc(string(data))), info.Mode().Perm()); err != nil {
+			return nil, fmt.Errorf("failed to write note %s: %w", noteNames[note], err)
+		}
+		change.Notes++
+	}
+
+	// todo.md
+	srcTodo, err := readOptional(filepath.Join(srcDir, "todo.md"))
+	if err != nil {
+		return nil, err
+	}
+	dstTodo, err := readOptional(filepath.Join(staged, "todo.md"))
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(srcTodo, "\n") {
+		if taskLinePattern.MatchString(line) {
+			change.Tasks++
+		}
+	}
+	if strings.TrimSpace(srcTodo) != "" {
+		merged := mergeTaskSections(dstTodo, fromSrc(srcTodo))
+		if err := os.WriteFile(filepath.Join(staged, "todo.md"), []byte(merged), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write todo.md: %w", err)
+		}
+	}
+
+	// notes.md
+	srcNotesFile, err := readOptional(filepath.Join(srcDir, "notes.md"))
+	if err != nil {
+		return nil, err
+	}
+	if body := stripTitle(srcNotesFile); strings.TrimSpace(body) != "" {
+		dstNotesFile, err := readOptional(filepath.Join(staged, "notes.md"))
+		if err != nil {
+			return nil, err
+		}
+		if dstNotesFile != "" && !strings.HasSuffix(dstNotesFile, "\n") {
+			dstNotesFile += "\n"
+		}
+		dstNotesFile += fmt.Sprintf("\n## From %s\n\n%s\n", src, strings.Trim(fromSrc(body), "\n"))
+		if err := os.WriteFile(filepath.Join(staged, "notes.md"), []byte(dstNotesFile), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write notes.md: %w", err)
+		}
+	}
+
+	// .repos
+	srcRepos, err := readOptional(filepath.Join(srcDir, ".repos"))
+	if err != nil {
+		return nil, err
+	}
+	dstRepos, err := readOptional(filepath.Join(staged, ".repos"))
+	if err != nil {
+		return nil, err
+	}
+	if repos := unionRepoLines(dstRepos, srcRepos); repos != dstRepos {
+		if err := os.WriteFile(filepath.Join(staged, ".repos"), []byte(repos), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write .repos: %w", err)
+		}
+	}
+
+	// Everything else: dst's .project.json wins, other files are copied alongside
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", src, err)
+	}
+	for _, entry := range entries {
+		switch entry.Name() {
+		case "todo.md", "notes.md", "notes", ".repos", AttachmentsDirName:
+			continue
+		case ProjectMetaFile:
+			if fileutil.FileExists(filepath.Join(staged, ProjectMetaFile)) {
+				continue
+			}
+		}
+		target := uniqueFileName(staged, entry.Name(), nil)
+		if target != entry.Name() {
+			change.Renamed[entry.Name()] = target
+		}
+		if err := copyTree(filepath.Join(srcDir, entry.Name()), filepath.Join(staged, target)); err != nil {
+			return nil, fmt.Errorf("failed to copy %s: %w", entry.Name(), err)
+		}
+	}
+
+	return renamedNotes, nil
+}
+
+// mergeAttachments copies attachments, reusing identical files already present
+// Returns attachments that got a different name (old -> new).
+func mergeAttachments(srcDir, dstDir string) (map[string]string, error) {
+	renamed := make(map[string]string)
+
+	entries, err := os.ReadDir(srcDir)
+	if os.IsNotExist(err) {
+		return renamed, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachments: %w", err)
+	}
+	if err := fileutil.EnsureDir(dstDir); err != nil {
+		return nil, fmt.Errorf("failed to create attachments directory: %w", err)
+	}
+
+	existing := make(map[string]string) // Content hash -> name in dst
+	if dstEntries, err := os.ReadDir(dstDir); err == nil {
+		for _, entry := range dstEntries {
+			if data, err := os.ReadFile(filepath.Join(dstDir, entry.Name())); err == nil && entry.Type().IsRegular() {
+				existing[contentHash(data)] = entry.Name()
+			}
+		}
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(srcDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read attachment %s: %w", entry.Name(), err)
+		}
+
+		name := entry.Name()
+		hash := contentHash(data)
+		if same, ok := existing[hash]; ok {
+			if same != name {
+				renamed[name] = same
+			}
+			continue
+		}
+		if fileutil.FileExists(filepath.Join(dstDir, name)) {
+			ext := filepath.Ext(name)
+			name = fmt.Sprintf("%s-%s%s", strings.TrimSuffix(name, ext), hash[:8], ext)
+			renamed[entry.Name()] = name
+		}
+		if err := os.WriteFile(filepath.Join(dstDir, name), data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to copy attachment %s: %w", name, err)
+		}
+		existing[hash] = name
+	}
+
+	return renamed, nil
+}
+
+// renameAttachmentLinks points markdown links at renamed attachments
+func renameAttachmentLinks(content string, renamed map[string]string) string {
+	if len(renamed) == 0 {
+		return content
+	}
+	return markdownLinkPattern.ReplaceAllStringFunc(content, func(match string) string {
+		parts := markdownLinkPattern.FindStringSubmatch(match)
+		dir, name := filepath.Split(parts[2])
+		newName, ok := renamed[name]
+		if !ok || filepath.Base(filepath.Clean(dir)) != AttachmentsDirName {
+			return match
+		}
+		return parts[1] + dir + newName + parts[3]
+	})
+}
+
+// mergeTaskSections appends the tasks of each src section to the dst section
+// with the same heading, adding sections dst does not have
+func mergeTaskSections(dst, src string) string {
+	dstSections := splitSections(dst)
+
+	for i, section := range splitSections(src) {
+		var items []string
+		for _, line := range section.lines {
+			if strings.TrimSpace(line) == "" || (i == 0 && strings.HasPrefix(line, "# ")) {
+				continue
+			}
+			items = append(items, line)
+		}
+		if len(items) == 0 {
+			continue
+		}
+
+		target := -1
+		for j := range dstSections {
+			if strings.EqualFold(dstSections[j].heading, section.heading) {
+				target = j
+				break
+			}
+		}
+		if target == -1 {
+			dstSections = append(dstSections, taskSection{heading: section.heading, lines: []string{""}})
+			target = len(dstSections) - 1
+		}
+		dstSections[target].insert(items)
+	}
+
+	return joinSections(dstSections)
+}
+
+// taskSection is a "## heading" and the lines below it; the first section has no heading
+type taskSection struct {
+	heading string
+	lines   []string
+}
+
+// insert adds lines after the last non-blank line of the section
+func (s *taskSection) insert(items []string) {
+	last := -1
+	for i, line := range s.lines {
+		if strings.TrimSpace(line) != "" {
+			last = i
+		}
+	}
+
+	var head []string
+	if last == -1 || strings.HasPrefix(s.lines[last], "#") {
+		// Keep a blank line between a heading and the tasks
+		head = append(head, s.lines[:last+1]...)
+		head = append(head, "")
+	} else {
+		head = append(head, s.lines[:last+1]...)
+	}
+
+	tail := s.lines[last+1:]
+	if len(tail) == 0 || strings.TrimSpace(tail[0]) != "" {
+		tail = append([]string{""}, tail...)
+	}
+
+	s.lines = append(append(head, items...), tail...)
+}
+
+func splitSections(content string) []taskSection {
+	sections := []taskSection{{}}
+	content = strings.TrimRight(content, "\n")
+	if content == "" {
+		return sections
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			sections = append(sections, taskSection{heading: strings.TrimSpace(line[3:])})
+			continue
+		}
+		current := &sections[len(sections)-1]
+		current.lines = append(current.lines, line)
+	}
+	return sections
+}
+
+func joinSections(sections []taskSection) string {
+	var lines []string
+	for i, section := range sections {
+		if i > 0 {
+			if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+				lines = append(lines, "")
+			}
+			lines = append(lines, "## "+section.heading)
+		}
+		lines = append(lines, section.lines...)
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// stripTitle removes a leading "# Title" line
+func stripTitle(content string) string {
+	if strings.HasPrefix(content, "# ") {
+		_, rest, _ := strings.Cut(content, "\n")
+		return rest
+	}
+	return content
+}
+
+// unionRepoLines appends the .repos lines of b whose URL a does not link yet
+// Lines are compared on the parsed URL, so the same repository with other
+// path= or branch= options is kept once (a's line wins). Comments and lines
+// that do not parse are compared verbatim.
+func unionRepoLines(a, b string) string {
+	key := func(line string) string {
+		if gitURL, _, err := ParseRepoLine(line); err == nil && gitURL != "" {
+			return gitURL
+		}
+		return line
+	}
+
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(a, "\n") {
+		seen[key(strings.TrimSpace(line))] = true
+	}
+
+	result := a
+	for _, line := range strings.Split(b, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || seen[key(line)] {
+			continue
+		}
+		if result != "" && !strings.HasSuffix(result, "\n") {
+			result += "\n"
+		}
+		result += line + "\n"
+		seen[key(line)] = true
+	}
+	return result
+}
+
+// uniqueFileName returns name, or name with a -2, -3, ... suffix before the extension
+// if it exists in dir or is in taken
+func uniqueFileName(dir, name string, taken map[string]bool) string {
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	candidate := name
+	for i := 2; taken[candidate] || fileutil.FileExists(filepath.Join(dir, candidate)); i++ {
+		candidate = stem + "-" + strconv.Itoa(i) + ext
+	}
+	return candidate
+}
+
+// readOptional reads a file, returning "" if it does not exist
+func readOptional(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return string(data), nil
+}
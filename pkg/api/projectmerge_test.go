@@ -0,0 +1,297 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestMergeTaskSections(t *testing.T) {
+	dst := "# Tasks\n\n## Active\n\n- [ ] Dst task\n\n## Completed\n- [x] Dst done\n"
+	src := "# Tasks\n\n## Active\n\n- [ ] Src task\n  - [ ] Src subtask\n\n## Waiting\n\n- [-] Src blocked\n\n## Completed\n\n- [x] Src done\n"
+
+	got := mergeTaskSections(dst, src)
+	want := "# Tasks\n\n## Active\n\n- [ ] Dst task\n- [ ] Src task\n  - [ ] Src subtask\n\n## Completed\n- [x] Dst done\n- [x] Src done\n\n## Waiting\n\n- [-] Src blocked\n"
+	if got != want {
+		t.Errorf("Unexpected merge:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Files without headings are appended
+	if got := mergeTaskSections("- [ ] A\n", "- [ ] B\n"); got != "- [ ] A\n- [ ] B\n" {
+		t.Errorf("Unexpected merge without headings: %q", got)
+	}
+}
+
+func TestMergeProjects(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	now := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	siteDir := tb.AddProject("site")
+	tb.WriteFile(filepath.Join(siteDir, "todo.md"), "# Tasks\n\n## Active\n\n- [ ] Site task\n\n## Completed\n")
+	tb.WriteFile(filepath.Join(siteDir, "notes.md"), "# site\n\nSite notes\n")
+	tb.WriteFile(filepath.Join(siteDir, "notes", "plan.md"), "# Site plan\n\nSee [[web/plan]]\n")
+	tb.WriteFile(filepath.Join(siteDir, ".repos"), "git@github.com:me/site.git\n")
+	tb.WriteFile(filepath.Join(siteDir, AttachmentsDirName, "logo.png"), "site logo")
+
+	webDir := tb.AddProject("web")
+	tb.WriteFile(filepath.Join(webDir, "todo.md"), "# Tasks\n\n## Active\n\n- [ ] Web task [[plan]]\n\n## Completed\n\n- [x] Web done\n")
+	tb.WriteFile(filepath.Join(webDir, "notes.md"), "# web\n\nWeb notes\n")
+	tb.WriteFile(filepath.Join(webDir, "notes", "plan.md"), "# Web plan\n\n![logo](../attachments/logo.png)\n")
+	tb.WriteFile(filepath.Join(webDir, "notes", "css.md"), "# CSS\n")
+	tb.WriteFile(filepath.Join(webDir, ".repos"), "git@github.com:me/site.git branch=main\ngit@github.com:me/web.git\n")
+	tb.WriteFile(filepath.Join(webDir, AttachmentsDirName, "logo.png"), "web logo")
+
+	apiDir := tb.AddProject("api")
+	tb.WriteFile(filepath.Join(apiDir, "todo.md"), "- [ ] Check [[web/css]] and [[web/plan#Goals]]\n")
+	tb.WriteFile(filepath.Join(tb.BrainPath, "refile-rules"), "tag:css -> web\n")
+	tb.AddToDump("- [ ] Tweak +web header\n")
+
+	change, err := MergeProjects(tb.BrainPath, "web", "site", "", now)
+	if err != nil {
+		t.Fatalf("MergeProjects failed: %v", err)
+	}
+	if change.Tasks != 2 || change.Notes != 2 {
+		t.Errorf("Expected 2 tasks and 2 notes, got %d and %d", change.Tasks, change.Notes)
+	}
+	if tb.FileExists(webDir) {
+		t.Error("Source project should be removed")
+	}
+
+	read := func(path string) string {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	checks := map[string][]string{
+		filepath.Join(siteDir, "todo.md"):            {"- [ ] Site task\n- [ ] Web task [[plan-2]]\n", "- [x] Web done"},
+		filepath.Join(siteDir, "notes.md"):           {"Site notes", "## From web\n\nWeb notes"},
+		filepath.Join(siteDir, "notes", "plan.md"):   {"# Site plan", "See [[site/plan-2]]"},
+		filepath.Join(siteDir, "notes", "plan-2.md"): {"# Web plan", "../attachments/logo-"},
+		filepath.Join(siteDir, "notes", "css.md"):    {"# CSS"},
+		filepath.Join(siteDir, ".repos"):             {"me/site.git\ngit@github.com:me/web.git\n"},
+		filepath.Join(apiDir, "todo.md"):             {"[[site/css]]", "[[site/plan-2#Goals]]"},
+		filepath.Join(tb.BrainPath, "refile-rules"):  {"tag:css -> site"},
+		tb.DumpPath: {"Tweak +site header"},
+		filepath.Join(siteDir, AttachmentsDirName, "logo.png"): {"site logo"},
+	}
+	for path, wants := range checks {
+		content := read(path)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s: expected %q in:\n%s", filepath.Base(path), want, content)
+			}
+		}
+	}
+	if strings.Count(read(filepath.Join(siteDir, ".repos")), "me/site.git") != 1 {
+		t.Error("Expected .repos to be unioned without duplicates")
+	}
+
+	// Undo restores both projects and the rewritten files
+	undone, err := UndoProjectChange(tb.BrainPath, false)
+	if err != nil {
+		t.Fatalf("UndoProjectChange failed: %v", err)
+	}
+	if undone.ID != change.ID {
+		t.Errorf("Expected to undo %s, got %s", change.ID, undone.ID)
+	}
+	if read(filepath.Join(webDir, "notes", "plan.md")) != "# Web plan\n\n![logo](../attachments/logo.png)\n" {
+		t.Error("Source project not restored")
+	}
+	if tb.FileExists(filepath.Join(siteDir, "notes", "plan-2.md")) {
+		t.Error("Merged notes should be gone after undo")
+	}
+	if !strings.Contains(read(filepath.Join(apiDir, "todo.md")), "[[web/css]]") {
+		t.Error("Links in other projects not restored")
+	}
+	if read(filepath.Join(tb.BrainPath, "refile-rules")) != "tag:css -> web\n" {
+		t.Error("Refile rules not restored")
+	}
+
+	if _, err := UndoProjectChange(tb.BrainPath, false); err == nil {
+		t.Error("Expected nothing to undo")
+	}
+}
+
+func TestMergeProjectsNoteCollisions(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	dstDir := tb.AddProject("dst")
+	tb.WriteFile(filepath.Join(dstDir, "notes", "a.md"), "DST A")
+	srcDir := tb.AddProject("src")
+	tb.WriteFile(filepath.Join(srcDir, "notes", "a.md"), "SRC A")
+	tb.WriteFile(filepath.Join(srcDir, "notes", "a-2.md"), "SRC A2")
+
+	if _, err := MergeProjects(tb.BrainPath, "src", "dst", "", time.Now()); err != nil {
+		t.Fatalf("MergeProjects failed: %v", err)
+	}
+
+	// Every note survives under its own name
+	contents := map[string]bool{}
+	entries, _ := os.ReadDir(filepath.Join(dstDir, "notes"))
+	for _, entry := range entries {
+		contents[tb.ReadFile(filepath.Join(dstDir, "notes", entry.Name()))] = true
+	}
+	if len(entries) != 3 || !contents["DST A"] || !contents["SRC A"] || !contents["SRC A2"] {
+		t.Errorf("Expected all 3 notes after the merge, got %d: %v", len(entries), contents)
+	}
+}
+
+func TestMergeProjectsErrors(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	now := time.Now()
+	tb.AddProject("web")
+
+	if _, err := MergeProjects(tb.BrainPath, "web", "web", "", now); err == nil {
+		t.Error("Expected error when merging a project into itself")
+	}
+	if _, err := MergeProjects(tb.BrainPath, "web", "missing", "", now); err == nil {
+		t.Error("Expected error for unknown destination")
+	}
+
+	// Undo refuses when a project was recreated after the merge
+	tb.AddProject("site")
+	if _, err := MergeProjects(tb.BrainPath, "web", "site", "", now); err != nil {
+		t.Fatalf("MergeProjects failed: %v", err)
+	}
+	tb.AddProject("web")
+	if _, err := UndoProjectChange(tb.BrainPath, false); err == nil {
+		t.Error("Expected undo to refuse after the source was recreated")
+	}
+}
+
+func TestUndoProjectChangeOrder(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	now := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	webDir := tb.AddProject("web")
+	tb.AddProject("site")
+	tb.WriteFile(filepath.Join(webDir, "todo.md"), "- [ ] One\n- [ ] Two\n")
+
+	items, err := SplitCandidates(webDir)
+	if err != nil {
+		t.Fatalf("SplitCandidates failed: %v", err)
+	}
+	// Changes within the same second are undone newest first
+	if _, err := SplitProject(tb.BrainPath, "web", "front", items[:1], now); err != nil {
+		t.Fatalf("SplitProject failed: %v", err)
+	}
+	if _, err := MergeProjects(tb.BrainPath, "front", "site", "", now); err != nil {
+		t.Fatalf("MergeProjects failed: %v", err)
+	}
+
+	for _, op := range []string{"merge", "split"} {
+		change, err := UndoProjectChange(tb.BrainPath, false)
+		if err != nil {
+			t.Fatalf("UndoProjectChange failed: %v", err)
+		}
+		if change.Op != op {
+			t.Errorf("Expected to undo %s, got %s", op, change.Op)
+		}
+	}
+	if tb.FileExists(filepath.Join(tb.ActiveDirPath, "front")) {
+		t.Error("Split project should be gone")
+	}
+}
+
+func TestUndoProjectChangeEditedSince(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	now := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	tb.AddProject("web")
+	siteDir := tb.AddProject("site")
+	tb.WriteFile(filepath.Join(tb.ActiveDirPath, "web", "notes", "spec.md"), "# Spec\n")
+	tb.AddToDump("- [ ] Read [[web/spec]]\n")
+
+	if _, err := MergeProjects(tb.BrainPath, "web", "site", "", now); err != nil {
+		t.Fatalf("MergeProjects failed: %v", err)
+	}
+
+	// A capture after the merge would be lost by restoring the dump snapshot
+	tb.AddToDump("- [ ] Captured after the merge\n")
+	if _, err := UndoProjectChange(tb.BrainPath, false); err == nil || !strings.Contains(err.Error(), "00_dump.md") {
+		t.Fatalf("Expected undo to refuse after the dump was edited, got %v", err)
+	}
+	if !tb.FileExists(filepath.Join(siteDir, "notes", "spec.md")) {
+		t.Error("Refused undo should leave the merge in place")
+	}
+
+	// Edits to the merged project are detected too
+	dump, _ := os.ReadFile(tb.DumpPath)
+	tb.WriteFile(tb.DumpPath, strings.Replace(string(dump), "- [ ] Captured after the merge\n", "", 1))
+	tb.WriteFile(filepath.Join(siteDir, "notes", "new.md"), "# New\n")
+	if _, err := UndoProjectChange(tb.BrainPath, false); err == nil || !strings.Contains(err.Error(), siteDir) {
+		t.Fatalf("Expected undo to refuse after the project was edited, got %v", err)
+	}
+
+	if _, err := UndoProjectChange(tb.BrainPath, true); err != nil {
+		t.Fatalf("Forced UndoProjectChange failed: %v", err)
+	}
+	if tb.FileExists(filepath.Join(siteDir, "notes", "new.md")) || !tb.FileExists(filepath.Join(tb.ActiveDirPath, "web", "notes", "spec.md")) {
+		t.Error("Forced undo should restore the snapshot")
+	}
+}
+
+func TestApplyProjectChangeKeepsConcurrentCapture(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	tb.AddProject("web")
+	tb.AddToDump("- [ ] Fix header +web\n")
+
+	rewrites, err := projectReferenceRewrites(tb.BrainPath, tb.ActiveDirPath, "web", "site", nil)
+	if err != nil {
+		t.Fatalf("projectReferenceRewrites failed: %v", err)
+	}
+
+	// A capture lands between computing the change and applying it
+	tb.AddToDump("- [ ] New idea +web\n")
+
+	change := &ProjectChange{Op: "merge"}
+	if err := applyProjectChange(tb.BrainPath, change, map[string]string{}, rewrites, time.Now()); err != nil {
+		t.Fatalf("applyProjectChange failed: %v", err)
+	}
+	if dump := tb.ReadFile(tb.DumpPath); !strings.Contains(dump, "New idea +site") {
+		t.Errorf("Capture lost by the change:\n%s", dump)
+	}
+
+	// Undo restores the dump as it was when the change was applied, capture included
+	if _, err := UndoProjectChange(tb.BrainPath, false); err != nil {
+		t.Fatalf("UndoProjectChange failed: %v", err)
+	}
+	if dump := tb.ReadFile(tb.DumpPath); !strings.Contains(dump, "Fix header +web") || !strings.Contains(dump, "New idea +web") {
+		t.Errorf("Unexpected dump after undo:\n%s", dump)
+	}
+}
+
+func TestMergeProjectsRecordsFocus(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	tb.AddProject("web")
+	tb.AddProject("site")
+
+	if _, err := MergeProjects(tb.BrainPath, "web", "site", "web", time.Now()); err != nil {
+		t.Fatalf("MergeProjects failed: %v", err)
+	}
+
+	change, err := UndoProjectChange(tb.BrainPath, false)
+	if err != nil {
+		t.Fatalf("UndoProjectChange failed: %v", err)
+	}
+	if focus, ok := change.FocusAfterUndo("site"); !ok || focus != "web" {
+		t.Errorf("Expected focus to move back to web, got %q (%v)", focus, ok)
+	}
+	// Focus moved elsewhere since the merge is left alone
+	if _, ok := change.FocusAfterUndo("api"); ok {
+		t.Error("Expected no focus change when focus moved since")
+	}
+
+	// A merge that did not move the focus records nothing
+	if _, err := MergeProjects(tb.BrainPath, "web", "site", "api", time.Now()); err != nil {
+		t.Fatalf("MergeProjects failed: %v", err)
+	}
+	if change, err = UndoProjectChange(tb.BrainPath, false); err != nil || change.Focus != nil {
+		t.Errorf("Expected no focus record, got %+v (%v)", change.Focus, err)
+	}
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/dateutil"
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+)
+
+// ProjectMetaFile is the optional per-project metadata file
+const ProjectMetaFile = ".project.json"
+
+// Project statuses
+const (
+	ProjectStatusActive  = "active"
+	ProjectStatusOnHold  = "on-hold"
+	ProjectStatusSomeday = "someday"
+)
+
+// ProjectStatuses lists the valid project statuses
+var ProjectStatuses = []string{ProjectStatusActive, ProjectStatusOnHold, ProjectStatusSomeday}
+
+// ProjectMetaKeys lists the keys accepted by ProjectMeta.Set
+var ProjectMetaKeys = []string{"description", "status", "area", "owner", "start", "target", "links"}
+
+// ProjectMeta holds descriptive metadata about a project
+type ProjectMeta struct {
+	Description string   `json:"description,omitempty"`
+	Status      string   `json:"status,omitempty"`
+	Area        string   `json:"area,omitempty"`
+	Owner       string   `json:"owner,omitempty"`
+	Start       string   `json:"start,omitempty"`
+	Target      string   `json:"target,omitempty"`
+	Links       []string `json:"links,omitempty"`
+}
+
+// LoadProjectMeta reads a project's metadata; a missing file yields defaults
+func LoadProjectMeta(projectDir string) (ProjectMeta, error) {
+	var meta ProjectMeta
+
+	data, err := os.ReadFile(filepath.Join(projectDir, ProjectMetaFile))
+	if err != nil && !os.IsNotExist(err) {
+		return meta, fmt.Errorf("failed to read %s: %w", ProjectMetaFile, err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return meta, fmt.Errorf("failed to parse %s: %w", ProjectMetaFile, err)
+		}
+	}
+
+	if meta.Status == "" {
+		meta.Status = ProjectStatusActive
+	}
+	return meta, nil
+}
+
+// loadProjectMetaOrDefault reads a project's metadata for listings
+// Unreadable metadata should not hide the project: it gets the defaults and
+// the error is returned as text for a warning.
+func loadProjectMetaOrDefault(projectDir string) (ProjectMeta, string) {
+	meta, err := LoadProjectMeta(projectDir)
+	if err != nil {
+		return ProjectMeta{Status: ProjectStatusActive}, err.Error()
+	}
+	return meta, ""
+}
+
+// SaveProjectMeta writes a project's metadata atomically
+func SaveProjectMeta(projectDir string, meta ProjectMeta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	data = append(data, '\n')
+
+	if err := fileutil.AtomicWriteFile(filepath.Join(projectDir, ProjectMetaFile), data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ProjectMetaFile, err)
+	}
+	return nil
+}
+
+// Set updates a single field by key; an empty value clears it
+// Dates accept natural language (see dateutil.ParseNaturalDate) and links a comma-separated list
+func (m *ProjectMeta) Set(key, value string) error {
+	value = strings.TrimSpace(value)
+
+	switch strings.ToLower(key) {
+	case "description":
+		m.Description = value
+	case "area":
+		m.Area = value
+	case "owner":
+		m.Owner = value
+	case "status":
+		if value == "" {
+			value = ProjectStatusActive
+		}
+		value = strings.ToLower(value)
+		if !containsFold(ProjectStatuses, value) {
+			return fmt.Errorf("invalid status '%s' (valid: %s)", value, strings.Join(ProjectStatuses, ", "))
+		}
+		m.Status = value
+	case "start", "target":
+		date := ""
+		if value != "" {
+			parsed, err := dateutil.ParseNaturalDate(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s date: %w", key, err)
+			}
+			date = parsed
+		}
+		if strings.ToLower(key) == "start" {
+			m.Start = date
+		} else {
+			m.Target = date
+		}
+	case "links":
+		m.Links = nil
+		for _, link := range strings.Split(value, ",") {
+			if link = strings.TrimSpace(link); link != "" {
+				m.Links = append(m.Links, link)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown key '%s' (valid: %s)", key, strings.Join(ProjectMetaKeys, ", "))
+	}
+
+	return nil
+}
+
+// SetProjectMeta updates one metadata key of a project and saves it
+func SetProjectMeta(projectDir, key, value string) (ProjectMeta, error) {
+	meta, err := LoadProjectMeta(projectDir)
+	if err != nil {
+		return meta, err
+	}
+	if err := meta.Set(key, value); err != nil {
+		return meta, err
+	}
+	return meta, SaveProjectMeta(projectDir, meta)
+}
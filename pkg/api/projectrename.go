@@ -0,0 +1,162 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+)
+
+// RenameResult describes what RenameProject changed
+type RenameResult struct {
+	Path    string   // New project directory
+	Updated []string // Files whose references to the project were rewritten
+}
+
+// RenameProject renames a project directory in its container and rewrites
+// references to it: [[old/note]] links in notes, task files, the dump and
+// the journal, +old routing in the dump, refile-rules targets and the
+// refile history. All rewrites are computed before anything is changed, and
+// the rename is rolled back if writing a file fails. Each file is rewritten
+// under its lock, so concurrent captures into the dump are not lost.
+func RenameProject(brainPath, oldName, newName string) (*RenameResult, error) {
+	activeDir := filepath.Join(brainPath, ActiveDirName)
+
+	oldDir, err := FindProjectDir(activeDir, oldName)
+	if err != nil {
+		return nil, err
+	}
+	if oldName == newName {
+		return nil, fmt.Errorf("project is already named '%s'", newName)
+	}
+	if existing, err := FindProjectDir(activeDir, newName); err == nil {
+		return nil, fmt.Errorf("project '%s' already exists (%s)", newName, existing)
+	}
+	newDir := filepath.Join(filepath.Dir(oldDir), newName)
+	if fileutil.FileExists(newDir) {
+		return nil, fmt.Errorf("'%s' already exists", newDir)
+	}
+
+	rewrites, err := projectReferenceRewrites(brainPath, activeDir, oldName, newName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Rename(oldDir, newDir); err != nil {
+		return nil, fmt.Errorf("failed to rename project: %w", err)
+	}
+
+	// Files inside the project moved with it
+	var written []fileRewrite
+	for _, rewrite := range rewrites {
+		if rel, err := filepath.Rel(oldDir, rewrite.path); err == nil && !strings.HasPrefix(rel, "..") {
+			rewrite.path = filepath.Join(newDir, rel)
+		}
+
+		if err := writeRewrite(&rewrite, nil); err != nil {
+			rollbackRename(written, oldDir, newDir)
+			return nil, fmt.Errorf("failed to update %s, rename rolled back: %w", rewrite.path, err)
+		}
+		written = append(written, rewrite)
+	}
+
+	// The refile index caches terms by project name; it is rebuilt on the next refile
+	if len(written) > 0 {
+		os.Remove(refileStatePath(brainPath, similarityIndexFile))
+	}
+
+	result := &RenameResult{Path: newDir}
+	for _, rewrite := range written {
+		result.Updated = append(result.Updated, rewrite.path)
+	}
+	sort.Strings(result.Updated)
+
+	return result, nil
+}
+
+// rollbackRename restores rewritten files and moves the project back
+// Files edited since they were rewritten (e.g. a capture into the dump) are kept.
+func rollbackRename(written []fileRewrite, oldDir, newDir string) {
+	for _, rewrite := range written {
+		restoreFile(rewrite.path, []byte(rewrite.original), contentHash([]byte(rewrite.updated)), false)
+	}
+	os.Rename(newDir, oldDir)
+}
+
+// projectReferenceRewrites computes the content changes needed to rename a project
+// renamedNotes maps lowercase note names that change as well (e.g. on a merge
+// collision); projects in skip are left out.
+func projectReferenceRewrites(brainPath, activeDir, oldName, newName string, renamedNotes map[string]string, skip ...string) ([]fileRewrite, error) {
+	files, err := brainTextFiles(brainPath, activeDir, skip...)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpPath := filepath.Join(brainPath, "00_dump.md")
+	routing := regexp.MustCompile(`(^|\s)\+` + regexp.QuoteMeta(oldName) + `(\s|$)`)
+
+	rewrites, err := rewriteFiles(files, func(path, content string) string {
+		content = relinkNotes(content, func(project, note string) (string, bool) {
+			if !strings.EqualFold(project, oldName) {
+				return "", false
+			}
+			if renamed, ok := renamedNotes[noteStem(note)]; ok {
+				note = renamed
+			}
+			return newName + "/" + note, true
+		})
+		if path == dumpPath {
+			content = routing.ReplaceAllString(content, "${1}+"+newName+"${2}")
+		}
+		return content
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	more, err := rewriteFiles([]string{
+		filepath.Join(brainPath, "refile-rules"),
+		refileStatePath(brainPath, refileHistoryFile),
+	}, func(path, content string) string {
+		if filepath.Base(path) == refileHistoryFile {
+			return renameHistoryProject(content, oldName, newName)
+		}
+		return renameRuleTargets(content, oldName, newName)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(rewrites, more...), nil
+}
+
+// renameRuleTargets rewrites refile rules that send items to the old project
+func renameRuleTargets(content, oldName, newName string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		matcher, target, ok := splitRuleLine(line)
+		if ok && strings.TrimSpace(target) == oldName {
+			lines[i] = matcher + "-> " + newName
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renameHistoryProject rewrites the project column of refile history entries
+func renameHistoryProject(content, oldName, newName string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) == 3 && parts[1] == oldName {
+			lines[i] = parts[0] + "\t" + newName + "\t" + parts[2]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestRenameProject(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	webDir := tb.AddProject("web")
+	tb.WriteFile(filepath.Join(webDir, "notes", "design.md"), "# Design\n\nSee [[web/roadmap#Q1|plan]].\n")
+	tb.WriteFile(filepath.Join(webDir, "notes", "roadmap.md"), "# Roadmap\n")
+
+	apiDir := tb.AddProject("api")
+	tb.WriteFile(filepath.Join(apiDir, "todo.md"), "- [ ] Sync with [[Web/design]] and [[webapp/x]]\n")
+
+	tb.AddToDump("- [ ] Fix header +web #captured:2024-01-01\n- [ ] Check +webapp routing\n")
+	tb.WriteFile(filepath.Join(tb.BrainPath, JournalDirName, "2024-01-02.md"), "Worked on [[web/design]]\n")
+	tb.WriteFile(filepath.Join(tb.BrainPath, "refile-rules"), "# tag:x -> web\ntag:frontend -> web\nkeyword:api -> api\nregex:web->api -> web\n")
+	tb.WriteFile(refileStatePath(tb.BrainPath, refileHistoryFile), "2024-01-01\tweb\tfix css\n2024-01-01\tapi\tadd endpoint\n")
+	tb.WriteFile(refileStatePath(tb.BrainPath, similarityIndexFile), "{}")
+
+	result, err := RenameProject(tb.BrainPath, "web", "site")
+	if err != nil {
+		t.Fatalf("RenameProject failed: %v", err)
+	}
+
+	if result.Path != filepath.Join(tb.ActiveDirPath, "site") || tb.FileExists(webDir) {
+		t.Fatalf("Project directory not renamed: %s", result.Path)
+	}
+	if len(result.Updated) != 6 {
+		t.Errorf("Expected 6 updated files, got %v", result.Updated)
+	}
+
+	read := func(path string) string {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	checks := map[string][]string{
+		filepath.Join(result.Path, "notes", "design.md"):             {"[[site/roadmap#Q1|plan]]"},
+		filepath.Join(apiDir, "todo.md"):                             {"[[site/design]]", "[[webapp/x]]"},
+		tb.DumpPath:                                                  {"Fix header +site #captured", "+webapp routing"},
+		filepath.Join(tb.BrainPath, JournalDirName, "2024-01-02.md"): {"[[site/design]]"},
+		filepath.Join(tb.BrainPath, "refile-rules"):                  {"# tag:x -> web", "tag:frontend -> site", "keyword:api -> api", "regex:web->api -> site"},
+		refileStatePath(tb.BrainPath, refileHistoryFile):             {"\tsite\tfix css", "\tapi\tadd endpoint"},
+	}
+	for path, wants := range checks {
+		content := read(path)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s: expected %q in:\n%s", filepath.Base(path), want, content)
+			}
+		}
+	}
+
+	if tb.FileExists(refileStatePath(tb.BrainPath, similarityIndexFile)) {
+		t.Error("Expected refile index cache to be removed")
+	}
+}
+
+func TestRenameProjectCollisions(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	tb.AddProject("web")
+	tb.AddProject("api")
+	tb.WriteFile(filepath.Join(tb.BrainPath, "02_areas", "health", "todo.md"), "")
+
+	if _, err := RenameProject(tb.BrainPath, "web", "api"); err == nil {
+		t.Error("Expected error when renaming onto an existing project")
+	}
+	if _, err := RenameProject(tb.BrainPath, "web", "health"); err == nil {
+		t.Error("Expected error when the name exists in another container")
+	}
+	if _, err := RenameProject(tb.BrainPath, "missing", "new"); err == nil {
+		t.Error("Expected error for unknown project")
+	}
+
+	// Projects are renamed within their container
+	result, err := RenameProject(tb.BrainPath, "health", "fitness")
+	if err != nil {
+		t.Fatalf("RenameProject failed: %v", err)
+	}
+	if result.Path != filepath.Join(tb.BrainPath, "02_areas", "fitness") {
+		t.Errorf("Expected rename in 02_areas, got %s", result.Path)
+	}
+}
+
+func TestWriteRewriteKeepsConcurrentCapture(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	tb.AddProject("web")
+	tb.AddToDump("- [ ] Fix header +web\n")
+
+	rewrites, err := projectReferenceRewrites(tb.BrainPath, tb.ActiveDirPath, "web", "site", nil)
+	if err != nil || len(rewrites) != 1 {
+		t.Fatalf("Expected a dump rewrite, got %v (%v)", rewrites, err)
+	}
+
+	// A capture lands after the rewrite was computed
+	tb.AddToDump("- [ ] New idea +web\n")
+
+	if err := writeRewrite(&rewrites[0], nil); err != nil {
+		t.Fatalf("writeRewrite failed: %v", err)
+	}
+	dump := tb.ReadFile(tb.DumpPath)
+	if !strings.Contains(dump, "Fix header +site") || !strings.Contains(dump, "New idea +site") {
+		t.Errorf("Expected both tasks rewritten and kept:\n%s", dump)
+	}
+	if tb.FileExists(filepath.Join(tb.BrainPath, ".00_dump.md.lock")) {
+		t.Error("Expected the dump lock to be released")
+	}
+
+	// Rolling back leaves files edited since alone
+	tb.AddToDump("- [ ] Later +site\n")
+	if err := restoreFile(tb.DumpPath, []byte(rewrites[0].original), contentHash([]byte(rewrites[0].updated)), false); err == nil {
+		t.Error("Expected restore of an edited file to be refused")
+	}
+	if !strings.Contains(tb.ReadFile(tb.DumpPath), "Later +site") {
+		t.Error("Expected the later capture to be kept")
+	}
+}
@@ -0,0 +1,363 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+	"github.com/sandermoonemans/local-brain/pkg/markdown"
+)
+
+// topLevelTaskPattern matches a task that is not a subtask
+var topLevelTaskPattern = regexp.MustCompile(`^- \[(.)\] (.+)$`)
+
+// SplitItem is a task or note that SplitProject can move to a new project
+type SplitItem struct {
+	Kind  string `json:"kind"`  // "task" or "note"
+	Label string `json:"label"` // Task line or notes/<file>
+	Line  int    `json:"line,omitempty"`
+	Path  string `json:"path,omitempty"`
+	raw   string // Task line, to detect changes to todo.md
+	text  string // Content matched by filters
+}
+
+// SplitCandidates returns the tasks (with their subtasks) and notes of a project
+func SplitCandidates(projectDir string) ([]SplitItem, error) {
+	var items []SplitItem
+
+	todo, err := readOptional(filepath.Join(projectDir, "todo.md"))
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(todo, "\n")
+	for _, block := range taskBlocks(lines) {
+		items = append(items, SplitItem{
+			Kind:  "task",
+			Label: strings.TrimSpace(lines[block.start]),
+			Line:  block.start + 1,
+			raw:   lines[block.start],
+			text:  strings.Join(lines[block.start:block.end], "\n"),
+		})
+	}
+
+	notes, err := ListNotes(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range notes {
+		data, err := os.ReadFile(note.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", note.Filename, err)
+		}
+		items = append(items, SplitItem{
+			Kind:  "note",
+			Label: "notes/" + note.Filename,
+			Path:  note.Path,
+			text:  string(data),
+		})
+	}
+
+	return items, nil
+}
+
+// FilterSplitItems keeps the items matching any of the '<kind>:<pattern>' expressions
+// Expressions use the refile rule syntax: tag:<tag>, keyword:<text> or regex:<re>
+func FilterSplitItems(items []SplitItem, exprs []string) ([]SplitItem, error) {
+	var rules []*RefileRule
+	for _, expr := range exprs {
+		rule, err := ParseMatcher(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+
+	var matched []SplitItem
+	for _, item := range items {
+		dumpItem := markdown.DumpItem{Type: markdown.ItemTypeTodo, Content: item.text}
+		if item.Kind == "note" {
+			dumpItem.Type = markdown.ItemTypeNote
+		}
+		for _, rule := range rules {
+			if rule.Matches(dumpItem) {
+				matched = append(matched, item)
+				break
+			}
+		}
+	}
+
+	return matched, nil
+}
+
+// SplitProject moves the selected tasks and notes of src into a new project
+// The new project is created in src's container with src's todo.md headings,
+// its .repos and the attachments the moved content links to. Links to moved
+// notes are rewritten. The original is kept so the split can be undone with
+// UndoProjectChange.
+func SplitProject(brainPath, src, newName string, items []SplitItem, now time.Time) (*ProjectChange, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no tasks or notes selected")
+	}
+
+	activeDir := filepath.Join(brainPath, ActiveDirName)
+	srcDir, err := FindProjectDir(activeDir, src)
+	if err != nil {
+		return nil, err
+	}
+	if existing, err := FindProjectDir(activeDir, newName); err == nil {
+		return nil, fmt.Errorf("project '%s' already exists (%s)", newName, existing)
+	}
+	newDir := filepath.Join(filepath.Dir(srcDir), newName)
+	if fileutil.FileExists(newDir) {
+		return nil, fmt.Errorf("'%s' already exists", newDir)
+	}
+
+	change := &ProjectChange{
+		Op: "split",
+	}
+
+	stagedSrc := stagingDir(srcDir, "split")
+	stagedNew := stagingDir(newDir, "new")
+	cleanup := func() {
+		os.RemoveAll(stagedSrc)
+		os.RemoveAll(stagedNew)
+	}
+
+	if err := copyTree(srcDir, stagedSrc); err != nil {
+		cleanup()
+		return nil, fmt.Errorf("failed to stage %s: %w", src, err)
+	}
+	if err := splitProjectInto(stagedSrc, stagedNew, src, newName, items, now, change); err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	moved := make(map[string]bool)
+	for _, item := range items {
+		if item.Kind == "note" {
+			moved[noteStem(filepath.Base(item.Path))] = true
+		}
+	}
+	files, err := brainTextFiles(brainPath, activeDir, srcDir)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	rewrites, err := rewriteFiles(files, func(_, content string) string {
+		return relinkNotes(content, func(project, note string) (string, bool) {
+			if !strings.EqualFold(project, src) || !moved[noteStem(note)] {
+				return "", false
+			}
+			return newName + "/" + note, true
+		})
+	})
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	change.Summary = fmt.Sprintf("split %d task(s) and %d note(s) from %s into %s", change.Tasks, change.Notes, src, newName)
+	if err := applyProjectChange(brainPath, change, map[string]string{srcDir: stagedSrc, newDir: stagedNew}, rewrites, now); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// splitProjectInto moves the selected items from the staged copy of src into the new project
+func splitProjectInto(stagedSrc, stagedNew, src, newName string, items []SplitItem, now time.Time, change *ProjectChange) error {
+	if err := os.MkdirAll(filepath.Join(stagedNew, "notes"), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", newName, err)
+	}
+
+	// todo.md: both projects keep the headings, the selected task blocks move
+	todo, err := readOptional(filepath.Join(stagedSrc, "todo.md"))
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(todo, "\n")
+	selected := make(map[int]bool)
+	for _, item := range items {
+		if item.Kind != "task" {
+			continue
+		}
+		if item.Line < 1 || item.Line > len(lines) || lines[item.Line-1] != item.raw {
+			return fmt.Errorf("todo.md changed since the tasks were listed; run the split again")
+		}
+		selected[item.Line-1] = true
+		change.Tasks++
+	}
+
+	var keep, move []string
+	blocks := taskBlocks(lines)
+	for i := 0; i < len(lines); i++ {
+		block, isTask := blockAt(blocks, i)
+		switch {
+		case isTask && selected[i]:
+			move = append(move, lines[block.start:block.end]...)
+			i = block.end - 1
+		case isTask:
+			keep = append(keep, lines[block.start:block.end]...)
+			i = block.end - 1
+		case strings.TrimSpace(lines[i]) == "" || strings.HasPrefix(lines[i], "#"):
+			keep = append(keep, lines[i])
+			move = append(move, lines[i])
+		default:
+			keep = append(keep, lines[i])
+		}
+	}
+
+	if change.Tasks > 0 {
+		if err := os.WriteFile(filepath.Join(stagedSrc, "todo.md"), []byte(strings.Join(keep, "\n")), 0644); err != nil {
+			return fmt.Errorf("failed to write todo.md: %w", err)
+		}
+	}
+	newTodo := strings.Join(collapseBlankLines(move), "\n")
+	if strings.TrimSpace(newTodo) == "" {
+		newTodo = "# Tasks\n\n## Active\n\n## Completed\n"
+	}
+	if err := os.WriteFile(filepath.Join(stagedNew, "todo.md"), []byte(newTodo), 0644); err != nil {
+		return fmt.Errorf("failed to write todo.md: %w", err)
+	}
+
+	// Notes
+	moved := make(map[string]bool)
+	for _, item := range items {
+		if item.Kind != "note" {
+			continue
+		}
+		name := filepath.Base(item.Path)
+		from := filepath.Join(stagedSrc, "notes", name)
+		if !fileutil.FileExists(from) {
+			return fmt.Errorf("note %s not found in %s", name, src)
+		}
+		if err := os.Rename(from, filepath.Join(stagedNew, "notes", name)); err != nil {
+			return fmt.Errorf("failed to move note %s: %w", name, err)
+		}
+		moved[noteStem(name)] = true
+		change.Notes++
+	}
+
+	remaining := make(map[string]bool)
+	if notes, err := filepath.Glob(filepath.Join(stagedSrc, "notes", "*.md")); err == nil {
+		for _, note := range notes {
+			remaining[noteStem(filepath.Base(note))] = true
+		}
+	}
+
+	// Bare links now cross projects: qualify them with the project they point to
+	err = rewriteFilesInPlace(projectSourceFiles(stagedSrc), func(content string) string {
+		return relinkNotes(content, func(project, note string) (string, bool) {
+			if (project == "" || strings.EqualFold(project, src)) && moved[noteStem(note)] {
+				return newName + "/" + note, true
+			}
+			return "", false
+		})
+	})
+	if err != nil {
+		return err
+	}
+	err = rewriteFilesInPlace(projectSourceFiles(stagedNew), func(content string) string {
+		return relinkNotes(content, func(project, note string) (string, bool) {
+			if project == "" && remaining[noteStem(note)] && !moved[noteStem(note)] {
+				return src + "/" + note, true
+			}
+			if strings.EqualFold(project, src) && moved[noteStem(note)] {
+				return note, true
+			}
+			return "", false
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	// New project files: default notes.md, src's .repos and linked attachments
+	vars := ProjectTemplateVars(newName, now)
+	if err := os.WriteFile(filepath.Join(stagedNew, "notes.md"), []byte(RenderTemplate(defaultProjectFiles["notes.md"], vars)), 0644); err != nil {
+		return fmt.Errorf("failed to write notes.md: %w", err)
+	}
+	repos, err := readOptional(filepath.Join(stagedSrc, ".repos"))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(stagedNew, ".repos"), []byte(repos), 0644); err != nil {
+		return fmt.Errorf("failed to write .repos: %w", err)
+	}
+
+	return copyLinkedAttachments(stagedSrc, stagedNew)
+}
+
+// copyLinkedAttachments copies the attachments linked from the new project's files
+func copyLinkedAttachments(fromDir, toDir string) error {
+	for _, file := range projectSourceFiles(toDir) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		for _, match := range markdownLinkPattern.FindAllStringSubmatch(string(data), -1) {
+			dir, name := filepath.Split(match[2])
+			if filepath.Base(filepath.Clean(dir)) != AttachmentsDirName {
+				continue
+			}
+			from := filepath.Join(fromDir, AttachmentsDirName, name)
+			to := filepath.Join(toDir, AttachmentsDirName, name)
+			if !fileutil.FileExists(from) || fileutil.FileExists(to) {
+				continue
+			}
+			if err := fileutil.EnsureDir(filepath.Dir(to)); err != nil {
+				return fmt.Errorf("failed to create attachments directory: %w", err)
+			}
+			if err := copyTree(from, to); err != nil {
+				return fmt.Errorf("failed to copy attachment %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// taskBlock is a top-level task line and its indented subtasks and notes (end exclusive)
+type taskBlock struct {
+	start, end int
+}
+
+func taskBlocks(lines []string) []taskBlock {
+	var blocks []taskBlock
+	for i := 0; i < len(lines); i++ {
+		if !topLevelTaskPattern.MatchString(lines[i]) {
+			continue
+		}
+		end := i + 1
+		for end < len(lines) && lines[end] != "" && (lines[end][0] == ' ' || lines[end][0] == '\t') {
+			end++
+		}
+		blocks = append(blocks, taskBlock{start: i, end: end})
+		i = end - 1
+	}
+	return blocks
+}
+
+func blockAt(blocks []taskBlock, line int) (taskBlock, bool) {
+	for _, block := range blocks {
+		if block.start == line {
+			return block, true
+		}
+	}
+	return taskBlock{}, false
+}
+
+// collapseBlankLines reduces runs of blank lines to one
+func collapseBlankLines(lines []string) []string {
+	var result []string
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" && len(result) > 0 && strings.TrimSpace(result[len(result)-1]) == "" {
+			continue
+		}
+		result = append(result, line)
+	}
+	return result
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestSplitCandidatesAndFilter(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	webDir := tb.AddProject("web")
+	tb.WriteFile(filepath.Join(webDir, "todo.md"), "# Tasks\n\n## Active\n\n- [ ] Fix css #frontend\n  - [ ] Buttons\n- [ ] Deploy API\n")
+	tb.WriteFile(filepath.Join(webDir, "notes", "design.md"), "---\ntitle: Design\n---\n\nColors for the frontend\n")
+
+	items, err := SplitCandidates(webDir)
+	if err != nil {
+		t.Fatalf("SplitCandidates failed: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("Expected 2 tasks and 1 note, got %+v", items)
+	}
+	if items[0].Label != "- [ ] Fix css #frontend" || items[0].Line != 5 {
+		t.Errorf("Unexpected first task: %+v", items[0])
+	}
+	if items[2].Kind != "note" || items[2].Label != "notes/design.md" {
+		t.Errorf("Unexpected note: %+v", items[2])
+	}
+
+	matched, err := FilterSplitItems(items, []string{"tag:frontend", "keyword:colors"})
+	if err != nil {
+		t.Fatalf("FilterSplitItems failed: %v", err)
+	}
+	if len(matched) != 2 || matched[0].Line != 5 || matched[1].Kind != "note" {
+		t.Errorf("Unexpected matches: %+v", matched)
+	}
+
+	if _, err := FilterSplitItems(items, []string{"frontend"}); err == nil {
+		t.Error("Expected error for filter without kind")
+	}
+}
+
+func TestSplitProject(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	now := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	webDir := tb.AddProject("web")
+	tb.WriteFile(filepath.Join(webDir, "todo.md"), "# Tasks\n\n## Active\n\n- [ ] Fix css #frontend\n  - [ ] Buttons\n- [ ] Deploy API [[design]]\n\n## Completed\n\n- [x] Old css #frontend\n")
+	tb.WriteFile(filepath.Join(webDir, "notes", "design.md"), "# Design\n\n![mock](../attachments/mock.png)\nSee [[setup]]\n")
+	tb.WriteFile(filepath.Join(webDir, "notes", "setup.md"), "# Setup\n")
+	tb.WriteFile(filepath.Join(webDir, AttachmentsDirName, "mock.png"), "png")
+	tb.WriteFile(filepath.Join(webDir, ".repos"), "git@github.com:me/web.git\n")
+
+	apiDir := tb.AddProject("api")
+	tb.WriteFile(filepath.Join(apiDir, "todo.md"), "- [ ] Read [[web/design]] and [[web/setup]]\n")
+
+	items, err := SplitCandidates(webDir)
+	if err != nil {
+		t.Fatalf("SplitCandidates failed: %v", err)
+	}
+	selected, err := FilterSplitItems(items, []string{"tag:frontend", "regex:^# Design"})
+	if err != nil {
+		t.Fatalf("FilterSplitItems failed: %v", err)
+	}
+
+	change, err := SplitProject(tb.BrainPath, "web", "frontend", selected, now)
+	if err != nil {
+		t.Fatalf("SplitProject failed: %v", err)
+	}
+	if change.Tasks != 2 || change.Notes != 1 {
+		t.Errorf("Expected 2 tasks and 1 note, got %d and %d", change.Tasks, change.Notes)
+	}
+
+	newDir := filepath.Join(tb.ActiveDirPath, "frontend")
+	read := func(path string) string {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	if got := read(filepath.Join(webDir, "todo.md")); got != "# Tasks\n\n## Active\n\n- [ ] Deploy API [[frontend/design]]\n\n## Completed\n\n" {
+		t.Errorf("Unexpected source todo.md:\n%q", got)
+	}
+	if got := read(filepath.Join(newDir, "todo.md")); got != "# Tasks\n\n## Active\n\n- [ ] Fix css #frontend\n  - [ ] Buttons\n\n## Completed\n\n- [x] Old css #frontend\n" {
+		t.Errorf("Unexpected new todo.md:\n%q", got)
+	}
+	if got := read(filepath.Join(newDir, "notes", "design.md")); !strings.Contains(got, "See [[web/setup]]") {
+		t.Errorf("Expected link back to the source project:\n%s", got)
+	}
+	if tb.FileExists(filepath.Join(webDir, "notes", "design.md")) {
+		t.Error("Moved note should be gone from the source")
+	}
+	if !tb.FileExists(filepath.Join(newDir, AttachmentsDirName, "mock.png")) {
+		t.Error("Linked attachment not copied")
+	}
+	if read(filepath.Join(newDir, ".repos")) != "git@github.com:me/web.git\n" {
+		t.Error(".repos not copied")
+	}
+	if got := read(filepath.Join(apiDir, "todo.md")); got != "- [ ] Read [[frontend/design]] and [[web/setup]]\n" {
+		t.Errorf("Unexpected links in other project: %q", got)
+	}
+
+	if _, err := UndoProjectChange(tb.BrainPath, false); err != nil {
+		t.Fatalf("UndoProjectChange failed: %v", err)
+	}
+	if tb.FileExists(newDir) {
+		t.Error("New project should be removed by undo")
+	}
+	if !tb.FileExists(filepath.Join(webDir, "notes", "design.md")) || !strings.Contains(read(filepath.Join(webDir, "todo.md")), "Fix css") {
+		t.Error("Source project not restored")
+	}
+	if !strings.Contains(read(filepath.Join(apiDir, "todo.md")), "[[web/design]]") {
+		t.Error("Links in other projects not restored")
+	}
+}
+
+func TestSplitProjectErrors(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	now := time.Now()
+	webDir := tb.AddProject("web")
+	tb.AddProject("api")
+	tb.WriteFile(filepath.Join(webDir, "todo.md"), "- [ ] One\n- [ ] Two\n")
+
+	items, err := SplitCandidates(webDir)
+	if err != nil {
+		t.Fatalf("SplitCandidates failed: %v", err)
+	}
+
+	if _, err := SplitProject(tb.BrainPath, "web", "api", items[:1], now); err == nil {
+		t.Error("Expected error when the new project exists")
+	}
+	if _, err := SplitProject(tb.BrainPath, "web", "new", nil, now); err == nil {
+		t.Error("Expected error without selected items")
+	}
+
+	// todo.md changed after listing
+	tb.WriteFile(filepath.Join(webDir, "todo.md"), "- [ ] Zero\n- [ ] One\n- [ ] Two\n")
+	if _, err := SplitProject(tb.BrainPath, "web", "new", items[:1], now); err == nil {
+		t.Error("Expected error when todo.md changed")
+	}
+	if tb.FileExists(filepath.Join(tb.ActiveDirPath, "new")) {
+		t.Error("Failed split should not create the project")
+	}
+	entries, _ := os.ReadDir(tb.ActiveDirPath)
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			t.Errorf("Staging directory left behind: %s", entry.Name())
+		}
+	}
+}
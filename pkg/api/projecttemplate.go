@@ -0,0 +1,184 @@
+package api
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+)
+
+// NoProjectTemplate selects the built-in project files even when a default template is set
+const NoProjectTemplate = "none"
+
+// defaultProjectFiles are the files of a project created without a template
+var defaultProjectFiles = map[string]string{
+	"notes.md": `# {{project}}
+
+Created: {{date}}
+
+## Overview
+
+[Description]
+
+## Notes
+`,
+	"todo.md": `# Tasks
+
+## Active
+
+- [ ] Define project goals
+- [ ] Set up development environment
+
+## Completed
+`,
+	".repos": "",
+}
+
+// ProjectTemplatesDir returns the directory with project templates: <brain>/templates/projects
+func ProjectTemplatesDir(brainPath string) string {
+	return filepath.Join(brainPath, TemplatesDirName, "projects")
+}
+
+// ListProjectTemplates returns the names of available project templates
+func ListProjectTemplates(brainPath string) ([]string, error) {
+	entries, err := os.ReadDir(ProjectTemplatesDir(brainPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project templates: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// ProjectTemplateVars returns the variables available in project templates:
+// {{project}}, {{date}}, {{time}}, {{weekday}} and {{year}}
+func ProjectTemplateVars(project string, now time.Time) map[string]string {
+	return map[string]string{
+		"project": project,
+		"date":    now.Format("2006-01-02"),
+		"time":    now.Format("15:04"),
+		"weekday": now.Format("Monday"),
+		"year":    now.Format("2006"),
+	}
+}
+
+// CreateProject creates <activeDir>/<name> from a project template
+// An empty template (or "none") uses the built-in notes.md, todo.md and .repos.
+// Template files are copied with {{variables}} replaced in file names and text
+// files; built-in files fill in whatever the template does not provide. The
+// project is assembled in a hidden directory and renamed into place, so a
+// failure leaves no half-created project behind.
+func CreateProject(brainPath, activeDir, name, template string, now time.Time) error {
+	projectDir := filepath.Join(activeDir, name)
+	if fileutil.FileExists(projectDir) {
+		return fmt.Errorf("project '%s' already exists", name)
+	}
+	if existing, err := FindProjectDir(activeDir, name); err == nil {
+		return fmt.Errorf("project '%s' already exists in %s", name, filepath.Base(filepath.Dir(existing)))
+	}
+
+	templateDir := ""
+	if template != "" && template != NoProjectTemplate {
+		if strings.ContainsAny(template, `/\`) || strings.HasPrefix(template, ".") {
+			return fmt.Errorf("invalid template name '%s'", template)
+		}
+		templateDir = filepath.Join(ProjectTemplatesDir(brainPath), template)
+		if info, err := os.Stat(templateDir); err != nil || !info.IsDir() {
+			available, _ := ListProjectTemplates(brainPath)
+			if len(available) == 0 {
+				return fmt.Errorf("project template '%s' not found (no templates in %s)", template, ProjectTemplatesDir(brainPath))
+			}
+			return fmt.Errorf("project template '%s' not found. Available: %s", template, strings.Join(available, ", "))
+		}
+	}
+
+	stagingDir := filepath.Join(activeDir, "."+name+".new")
+	if err := os.RemoveAll(stagingDir); err != nil {
+		return fmt.Errorf("failed to clean staging directory: %w", err)
+	}
+	if err := os.MkdirAll(stagingDir, 0755); err != nil {
+		return fmt.Errorf("failed to create project directory: %w", err)
+	}
+
+	vars := ProjectTemplateVars(name, now)
+	if err := populateProject(stagingDir, templateDir, vars); err != nil {
+		os.RemoveAll(stagingDir)
+		return err
+	}
+
+	if err := os.Rename(stagingDir, projectDir); err != nil {
+		os.RemoveAll(stagingDir)
+		return fmt.Errorf("failed to create project directory: %w", err)
+	}
+
+	return nil
+}
+
+// populateProject copies the template into dir and adds missing default files
+func populateProject(dir, templateDir string, vars map[string]string) error {
+	if templateDir != "" {
+		err := filepath.WalkDir(templateDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(templateDir, path)
+			if err != nil || rel == "." {
+				return err
+			}
+			if d.IsDir() && d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			target := filepath.Join(dir, RenderTemplate(rel, vars))
+			if d.IsDir() {
+				return os.MkdirAll(target, 0755)
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if utf8.Valid(data) {
+				data = []byte(RenderTemplate(string(data), vars))
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			return os.WriteFile(target, data, 0644)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to copy project template: %w", err)
+		}
+	}
+
+	for file, content := range defaultProjectFiles {
+		path := filepath.Join(dir, file)
+		if fileutil.FileExists(path) {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(RenderTemplate(content, vars)), 0644); err != nil {
+			return fmt.Errorf("failed to create %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestCreateProjectDefault(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	now := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	if err := CreateProject(tb.BrainPath, tb.ActiveDirPath, "billing", "", now); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+
+	projectDir := filepath.Join(tb.ActiveDirPath, "billing")
+	for _, file := range []string{"notes.md", "todo.md", ".repos"} {
+		if !tb.FileExists(filepath.Join(projectDir, file)) {
+			t.Errorf("Expected %s to be created", file)
+		}
+	}
+
+	notes, _ := os.ReadFile(filepath.Join(projectDir, "notes.md"))
+	if !strings.HasPrefix(string(notes), "# billing\n\nCreated: 2024-03-04") {
+		t.Errorf("Unexpected notes.md:\n%s", notes)
+	}
+
+	if err := CreateProject(tb.BrainPath, tb.ActiveDirPath, "billing", "", now); err == nil {
+		t.Error("Expected error for existing project")
+	}
+}
+
+func TestCreateProjectFromTemplate(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	now := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+	templateDir := filepath.Join(ProjectTemplatesDir(tb.BrainPath), "release")
+	tb.WriteFile(filepath.Join(templateDir, "todo.md"), "# {{project}}\n\n- [ ] Tag {{project}} {{year}}\n")
+	tb.WriteFile(filepath.Join(templateDir, ".repos"), "git@github.com:acme/{{project}}.git\n")
+	tb.WriteFile(filepath.Join(templateDir, "notes", "{{date}}-kickoff.md"), "# Kickoff {{unknown}}\n")
+
+	names, err := ListProjectTemplates(tb.BrainPath)
+	if err != nil || len(names) != 1 || names[0] != "release" {
+		t.Fatalf("Expected [release], got %v (%v)", names, err)
+	}
+
+	if err := CreateProject(tb.BrainPath, tb.ActiveDirPath, "v2", "release", now); err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+
+	projectDir := filepath.Join(tb.ActiveDirPath, "v2")
+	read := func(rel string) string {
+		data, err := os.ReadFile(filepath.Join(projectDir, rel))
+		if err != nil {
+			t.Fatalf("Expected %s: %v", rel, err)
+		}
+		return string(data)
+	}
+
+	if got := read("todo.md"); got != "# v2\n\n- [ ] Tag v2 2024\n" {
+		t.Errorf("Unexpected todo.md: %q", got)
+	}
+	if got := read(".repos"); got != "git@github.com:acme/v2.git\n" {
+		t.Errorf("Unexpected .repos: %q", got)
+	}
+	if got := read("notes/2024-03-04-kickoff.md"); got != "# Kickoff {{unknown}}\n" {
+		t.Errorf("Unexpected kickoff note: %q", got)
+	}
+	// Missing files come from the built-in defaults
+	if !strings.HasPrefix(read("notes.md"), "# v2") {
+		t.Error("Expected built-in notes.md")
+	}
+}
+
+func TestCreateProjectUnknownTemplate(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	err := CreateProject(tb.BrainPath, tb.ActiveDirPath, "x", "missing", time.Now())
+	if err == nil {
+		t.Fatal("Expected error for unknown template")
+	}
+	if tb.FileExists(filepath.Join(tb.ActiveDirPath, "x")) {
+		t.Error("Project should not be created when the template is missing")
+	}
+
+	entries, _ := os.ReadDir(tb.ActiveDirPath)
+	if len(entries) != 0 {
+		t.Errorf("Expected no leftover directories, got %d", len(entries))
+	}
+}
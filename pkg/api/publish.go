@@ -0,0 +1,506 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+	"github.com/sandermoonemans/local-brain/pkg/markdown"
+)
+
+// DefaultPrivateTag marks tasks and notes that are never published
+const DefaultPrivateTag = "private"
+
+// PublishOptions controls what Publish renders
+type PublishOptions struct {
+	OutDir     string
+	Projects   []string // Projects to publish; empty means all active projects
+	Exclude    []string // Projects to leave out
+	PrivateTag string   // Tasks and notes with this tag are left out; empty disables
+}
+
+// PublishResult summarizes a publish run
+type PublishResult struct {
+	Projects []string
+	Pages    int
+	Removed  int // Stale pages and attachments from earlier runs
+}
+
+// publishStatuses is the order task groups appear in on a project page
+var publishStatuses = []struct {
+	status string
+	title  string
+}{
+	{"in-progress", "In Progress"},
+	{"open", "Open"},
+	{"blocked", "Blocked"},
+	{"done", "Done"},
+}
+
+// publishSite holds the state of one publish run
+type publishSite struct {
+	dirs      map[string]string // Project name -> directory
+	opts      PublishOptions
+	graph     *LinkGraph
+	published map[string]string // Note path -> page path relative to OutDir
+	linked    map[string]bool   // Attachments linked from published content
+	written   map[string]bool   // Files written by this run, relative to OutDir
+	pages     int
+}
+
+// Publish renders projects, their tasks and notes to a static HTML site
+// Layout: index.html, <project>/index.html, <project>/notes/<note>.html and
+// the attachments linked from published content. Pages and attachments left
+// over from earlier runs are removed, so content marked private later goes offline.
+func Publish(activeDir string, opts PublishOptions) (*PublishResult, error) {
+	projects, dirs, err := publishProjects(activeDir, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) == 0 {
+		return nil, fmt.Errorf("no projects to publish")
+	}
+
+	graph, err := BuildLinkGraph(activeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	todos, err := ParseAllTodos(activeDir, true)
+	if err != nil {
+		return nil, err
+	}
+
+	site := &publishSite{
+		dirs:      dirs,
+		opts:      opts,
+		graph:     graph,
+		published: make(map[string]string),
+		linked:    make(map[string]bool),
+		written:   make(map[string]bool),
+	}
+
+	// Register note pages first so wiki links can point across projects
+	notesByProject := make(map[string][]NoteFile)
+	for _, project := range projects {
+		notes, err := ListNotes(dirs[project])
+		if err != nil {
+			return nil, err
+		}
+		var public []NoteFile
+		for _, note := range notes {
+			if site.isPrivateNote(note) {
+				continue
+			}
+			public = append(public, note)
+			site.published[note.Path] = project + "/notes/" + strings.TrimSuffix(note.Filename, ".md") + ".html"
+		}
+		sort.Slice(public, func(i, j int) bool { return public[i].Created > public[j].Created })
+		notesByProject[project] = public
+	}
+
+	tasksByProject := make(map[string][]TodoItem)
+	for _, todo := range todos {
+		if site.opts.PrivateTag != "" && containsFold(todo.Tags, site.opts.PrivateTag) {
+			continue
+		}
+		tasksByProject[todo.Project] = append(tasksByProject[todo.Project], todo)
+	}
+
+	if err := fileutil.EnsureDir(opts.OutDir); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, project := range projects {
+		if err := site.writeProject(project, tasksByProject[project], notesByProject[project]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := site.writeIndex(projects, tasksByProject, notesByProject); err != nil {
+		return nil, err
+	}
+
+	removed, err := site.removeStale()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublishResult{Projects: projects, Pages: site.pages, Removed: removed}, nil
+}
+
+// publishProjects returns the selected projects, sorted, and their directories
+func publishProjects(activeDir string, opts PublishOptions) ([]string, map[string]string, error) {
+	found, err := listProjectDirs(activeDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var projects []string
+	dirs := make(map[string]string)
+	for _, dir := range found {
+		if len(opts.Projects) > 0 && !containsFold(opts.Projects, dir.Name) {
+			continue
+		}
+		if containsFold(opts.Exclude, dir.Name) {
+			continue
+		}
+		projects = append(projects, dir.Name)
+		dirs[dir.Name] = dir.Path
+	}
+
+	for _, wanted := range opts.Projects {
+		if !containsFold(projects, wanted) && !containsFold(opts.Exclude, wanted) {
+			return nil, nil, fmt.Errorf("project '%s' not found", wanted)
+		}
+	}
+
+	sort.Strings(projects)
+	return projects, dirs, nil
+}
+
+func (s *publishSite) isPrivateNote(note NoteFile) bool {
+	return s.opts.PrivateTag != "" && note.HasTag(s.opts.PrivateTag)
+}
+
+// renderer returns a markdown renderer for a page, resolving wiki links relative to it
+func (s *publishSite) renderer(project, pagePath string) *markdown.HTMLRenderer {
+	return &markdown.HTMLRenderer{
+		WikiLink: func(target string) (string, bool) {
+			note, _ := s.graph.Resolve(target, project)
+			if note == nil {
+				return "", false
+			}
+			page, ok := s.published[note.Path]
+			if !ok {
+				return "", false
+			}
+			return relativeHref(pagePath, page), true
+		},
+	}
+}
+
+func (s *publishSite) writeProject(project string, todos []TodoItem, notes []NoteFile) error {
+	projectDir := s.dirs[project]
+	pagePath := project + "/index.html"
+	r := s.renderer(project, pagePath)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "<h1>%s</h1>\n", html.EscapeString(project))
+	body.WriteString(taskSummaryHTML(todos))
+
+	for _, group := range publishStatuses {
+		var tasks []TodoItem
+		for _, todo := range todos {
+			if todo.Status == group.status {
+				tasks = append(tasks, todo)
+			}
+		}
+		if len(tasks) == 0 {
+			continue
+		}
+		sortTasksForPublish(tasks)
+
+		fmt.Fprintf(&body, "<h2>%s <span class=\"count\">%d</span></h2>\n<ul class=\"tasks\">\n", group.title, len(tasks))
+		for _, todo := range tasks {
+			body.WriteString(taskHTML(r, todo))
+			s.linkAttachments(todo.File, todo.Content)
+		}
+		body.WriteString("</ul>\n")
+	}
+
+	if len(notes) > 0 {
+		body.WriteString("<h2>Notes</h2>\n<ul class=\"notes\">\n")
+		for _, note := range notes {
+			fmt.Fprintf(&body, "<li><a href=\"%s\">%s</a> <span class=\"meta\">%s</span>%s</li>\n",
+				html.EscapeString(relativeHref(pagePath, s.published[note.Path])),
+				html.EscapeString(note.Title), html.EscapeString(note.Created), tagsHTML(note.Tags))
+		}
+		body.WriteString("</ul>\n")
+	}
+
+	notesPath := filepath.Join(projectDir, "notes.md")
+	if note, err := parseNoteFile(notesPath, project); err == nil && !s.isPrivateNote(note) {
+		data, err := os.ReadFile(notesPath)
+		if err != nil {
+			return fmt.Errorf("failed to read notes.md: %w", err)
+		}
+		_, content, _ := markdown.ParseFrontMatter(string(data))
+		if strings.TrimSpace(content) != "" {
+			fmt.Fprintf(&body, "<section class=\"project-notes\">\n%s</section>\n", r.Render(content))
+			s.linkAttachments(notesPath, content)
+		}
+	}
+
+	if err := s.writePage(pagePath, project, body.String()); err != nil {
+		return err
+	}
+
+	for _, note := range notes {
+		if err := s.writeNote(project, note); err != nil {
+			return err
+		}
+	}
+
+	return s.copyAttachments(project)
+}
+
+func (s *publishSite) writeNote(project string, note NoteFile) error {
+	data, err := os.ReadFile(note.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read note: %w", err)
+	}
+	_, content, _ := markdown.ParseFrontMatter(string(data))
+
+	pagePath := s.published[note.Path]
+	r := s.renderer(project, pagePath)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "<p class=\"breadcrumb\"><a href=\"../index.html\">%s</a></p>\n", html.EscapeString(project))
+	meta := note.Created
+	if note.Updated != "" && note.Updated != note.Created {
+		meta += " · updated " + note.Updated
+	}
+	fmt.Fprintf(&body, "<p class=\"meta\">%s%s</p>\n", html.EscapeString(meta), tagsHTML(note.Tags))
+	body.WriteString(r.Render(content))
+	s.linkAttachments(note.Path, content)
+
+	return s.writePage(pagePath, note.Title, body.String())
+}
+
+func (s *publishSite) writeIndex(projects []string, tasks map[string][]TodoItem, notes map[string][]NoteFile) error {
+	var body strings.Builder
+	body.WriteString("<h1>Projects</h1>\n<table class=\"projects\">\n")
+	body.WriteString("<tr><th>Project</th><th>In Progress</th><th>Open</th><th>Blocked</th><th>Done</th><th>Notes</th></tr>\n")
+
+	for _, project := range projects {
+		counts := countByStatus(tasks[project])
+		fmt.Fprintf(&body, "<tr><td><a href=\"%s/index.html\">%s</a></td><td>%d</td><td>%d</td><td>%d</td><td>%d</td><td>%d</td></tr>\n",
+			html.EscapeString(project), html.EscapeString(project),
+			counts["in-progress"], counts["open"], counts["blocked"], counts["done"], len(notes[project]))
+	}
+	body.WriteString("</table>\n")
+
+	return s.writePage("index.html", "Projects", body.String())
+}
+
+// writePage wraps a body in the site layout and writes it below OutDir
+func (s *publishSite) writePage(pagePath, title, body string) error {
+	root := relativeHref(pagePath, "index.html")
+
+	var page strings.Builder
+	page.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	page.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	fmt.Fprintf(&page, "<title>%s</title>\n<style>%s</style>\n</head>\n<body>\n", html.EscapeString(title), publishCSS)
+	fmt.Fprintf(&page, "<nav><a href=\"%s\">All projects</a></nav>\n<main>\n%s</main>\n", html.EscapeString(root), body)
+	fmt.Fprintf(&page, "<footer>Published %s</footer>\n</body>\n</html>\n", time.Now().Format("2006-01-02 15:04"))
+
+	path := filepath.Join(s.opts.OutDir, filepath.FromSlash(pagePath))
+	if err := fileutil.EnsureDir(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(page.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", pagePath, err)
+	}
+
+	s.written[pagePath] = true
+	s.pages++
+	return nil
+}
+
+// taskHTML renders a task with priority badge, tags and due or done date
+func taskHTML(r *markdown.HTMLRenderer, todo TodoItem) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<li class=\"task %s\">", todo.Status)
+	if todo.Priority != nil {
+		fmt.Fprintf(&sb, "<span class=\"prio p%d\">P%d</span> ", *todo.Priority, *todo.Priority)
+	}
+	sb.WriteString(r.RenderInline(todo.Content))
+	sb.WriteString(tagsHTML(todo.Tags))
+
+	switch {
+	case todo.Status == "done" && todo.DoneDate != "":
+		fmt.Fprintf(&sb, " <span class=\"date\">done %s</span>", html.EscapeString(todo.DoneDate))
+	case todo.Status != "done" && todo.DueDate != "":
+		class, label := "due", "due"
+		if todo.DueDate < time.Now().Format("2006-01-02") {
+			class, label = "due overdue", "overdue"
+		}
+		fmt.Fprintf(&sb, " <span class=\"%s\">%s %s</span>", class, label, html.EscapeString(todo.DueDate))
+	}
+
+	sb.WriteString("</li>\n")
+	return sb.String()
+}
+
+func taskSummaryHTML(todos []TodoItem) string {
+	counts := countByStatus(todos)
+	return fmt.Sprintf("<p class=\"summary\">%d in progress · %d open · %d blocked · %d done</p>\n",
+		counts["in-progress"], counts["open"], counts["blocked"], counts["done"])
+}
+
+func tagsHTML(tags []string) string {
+	var sb strings.Builder
+	for _, tag := range tags {
+		fmt.Fprintf(&sb, " <span class=\"tag\">#%s</span>", html.EscapeString(tag))
+	}
+	return sb.String()
+}
+
+func countByStatus(todos []TodoItem) map[string]int {
+	counts := make(map[string]int)
+	for _, todo := range todos {
+		counts[todo.Status]++
+	}
+	return counts
+}
+
+// sortTasksForPublish orders tasks by priority, then due date
+func sortTasksForPublish(tasks []TodoItem) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		pi, pj := 4, 4
+		if tasks[i].Priority != nil {
+			pi = *tasks[i].Priority
+		}
+		if tasks[j].Priority != nil {
+			pj = *tasks[j].Priority
+		}
+		if pi != pj {
+			return pi < pj
+		}
+		di, dj := tasks[i].DueDate, tasks[j].DueDate
+		if di == "" || dj == "" {
+			return di != ""
+		}
+		return di < dj
+	})
+}
+
+// relativeHref returns the link from one page to another, both relative to the site root
+func relativeHref(fromPage, toPage string) string {
+	depth := strings.Count(fromPage, "/")
+	return strings.Repeat("../", depth) + toPage
+}
+
+// linkAttachments records the attachments a published file links to
+func (s *publishSite) linkAttachments(sourcePath, content string) {
+	for _, path := range attachmentLinkTargets(sourcePath, content) {
+		s.linked[path] = true
+	}
+}
+
+// copyAttachments copies the linked attachments of a project below OutDir
+func (s *publishSite) copyAttachments(project string) error {
+	attachmentsDir := filepath.Join(s.dirs[project], AttachmentsDirName)
+	entries, err := os.ReadDir(attachmentsDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", attachmentsDir, err)
+	}
+
+	for _, entry := range entries {
+		src := filepath.Join(attachmentsDir, entry.Name())
+		if !entry.Type().IsRegular() || !s.linked[src] {
+			continue
+		}
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+		rel := project + "/" + AttachmentsDirName + "/" + entry.Name()
+		dst := filepath.Join(s.opts.OutDir, filepath.FromSlash(rel))
+		if err := fileutil.EnsureDir(filepath.Dir(dst)); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(dst), err)
+		}
+		if err := os.WriteFile(dst, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", entry.Name(), err)
+		}
+		s.written[rel] = true
+	}
+
+	return nil
+}
+
+// publishManifestFile lists the files the last run wrote, relative to OutDir
+const publishManifestFile = ".brain-publish.json"
+
+type publishManifest struct {
+	Files []string `json:"files"`
+}
+
+// removeStale deletes files the previous run wrote that this run did not
+// Only files listed in the previous manifest are touched, so publishing into a
+// directory with other content (e.g. a CNAME or an existing site) never
+// deletes it. Writes the manifest for the next run.
+func (s *publishSite) removeStale() (int, error) {
+	manifestPath := filepath.Join(s.opts.OutDir, publishManifestFile)
+
+	var previous publishManifest
+	if data, err := os.ReadFile(manifestPath); err == nil {
+		if err := json.Unmarshal(data, &previous); err != nil {
+			return 0, fmt.Errorf("failed to parse %s: %w", manifestPath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return 0, fmt.Errorf("failed to read %s: %w", manifestPath, err)
+	}
+
+	removed := 0
+	for _, rel := range previous.Files {
+		if s.written[rel] || !filepath.IsLocal(filepath.FromSlash(rel)) {
+			continue
+		}
+		path := filepath.Join(s.opts.OutDir, filepath.FromSlash(rel))
+		if err := os.Remove(path); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return removed, fmt.Errorf("failed to remove stale %s: %w", path, err)
+		}
+		removed++
+
+		// Remove parents the file leaves empty; non-empty directories stay
+		for dir := filepath.Dir(path); dir != filepath.Clean(s.opts.OutDir); dir = filepath.Dir(dir) {
+			if os.Remove(dir) != nil {
+				break
+			}
+		}
+	}
+
+	current := publishManifest{Files: make([]string, 0, len(s.written))}
+	for rel := range s.written {
+		current.Files = append(current.Files, rel)
+	}
+	sort.Strings(current.Files)
+	data, err := json.MarshalIndent(current, "", "  ")
+	if err != nil {
+		return removed, fmt.Errorf("failed to marshal publish manifest: %w", err)
+	}
+	if err := fileutil.AtomicWriteFile(manifestPath, data); err != nil {
+		return removed, fmt.Errorf("failed to write %s: %w", manifestPath, err)
+	}
+
+	return removed, nil
+}
+
+const publishCSS = `
+body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",Helvetica,Arial,sans-serif;max-width:52rem;margin:0 auto;padding:1rem 1.5rem;color:#1f2328;line-height:1.5}
+nav,footer{font-size:.85rem;color:#656d76}footer{margin-top:3rem;border-top:1px solid #d0d7de;padding-top:.5rem}
+a{color:#0969da;text-decoration:none}a:hover{text-decoration:underline}
+h2 .count{font-size:.8rem;background:#eaeef2;border-radius:1rem;padding:.1rem .5rem;vertical-align:middle}
+ul.tasks,ul.notes{list-style:none;padding-left:0}ul.tasks li,ul.notes li{padding:.2rem 0}
+li.task.done{color:#656d76;text-decoration:line-through}li.task.blocked{color:#9a6700}
+.prio{font-size:.75rem;font-weight:600;border-radius:.3rem;padding:0 .35rem;color:#fff}.p1{background:#cf222e}.p2{background:#bf8700}.p3{background:#57606a}
+.tag{font-size:.8rem;color:#0550ae}.meta,.date,.due,.summary{font-size:.85rem;color:#656d76}.overdue{color:#cf222e;font-weight:600}
+table.projects{border-collapse:collapse;width:100%}table.projects th,table.projects td{border-bottom:1px solid #d0d7de;padding:.35rem .5rem;text-align:left}
+pre{background:#f6f8fa;padding:.75rem;overflow:auto;border-radius:.4rem}code{background:#f6f8fa;padding:.1rem .3rem;border-radius:.3rem}pre code{padding:0}
+blockquote{border-left:.25rem solid #d0d7de;margin-left:0;padding-left:1rem;color:#656d76}
+.wikilink.broken{color:#cf222e;border-bottom:1px dashed}img{max-width:100%}
+`
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestPublish(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	web := tb.AddProject("web")
+	tb.AddProject("personal")
+
+	tb.WriteFile(filepath.Join(web, "todo.md"), `# web
+
+- [ ] Fix login #p:1 #due:2020-01-01
+- [>] Build nav, see [[design]]
+- [ ] Salary review #private
+- [x] Ship v1 #done:2024-03-01
+`)
+	tb.WriteFile(filepath.Join(web, "notes", "design.md"), "---\ntitle: Design\ncreated: 2024-01-15\n---\n# Design\n\nSee [[secret]].\n\n![shot](../attachments/shot.png)\n")
+	tb.WriteFile(filepath.Join(web, "notes", "secret.md"), "---\ntitle: Secret\ntags: [private]\n---\nHidden ![scan](../attachments/payslip.png)\n")
+	tb.WriteFile(filepath.Join(web, AttachmentsDirName, "shot.png"), "image")
+	tb.WriteFile(filepath.Join(web, AttachmentsDirName, "payslip.png"), "private image")
+	tb.WriteFile(filepath.Join(web, AttachmentsDirName, "unused.png"), "unlinked image")
+
+	outDir := filepath.Join(tb.TmpDir, "site")
+	result, err := Publish(tb.ActiveDirPath, PublishOptions{
+		OutDir:     outDir,
+		Exclude:    []string{"personal"},
+		PrivateTag: DefaultPrivateTag,
+	})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if len(result.Projects) != 1 || result.Projects[0] != "web" {
+		t.Errorf("Expected only web to be published, got %v", result.Projects)
+	}
+	if result.Pages != 3 {
+		t.Errorf("Expected 3 pages, got %d", result.Pages)
+	}
+
+	read := func(rel string) string {
+		data, err := os.ReadFile(filepath.Join(outDir, rel))
+		if err != nil {
+			t.Fatalf("Expected %s to exist: %v", rel, err)
+		}
+		return string(data)
+	}
+
+	index := read("index.html")
+	if !strings.Contains(index, `href="web/index.html"`) || strings.Contains(index, "personal") {
+		t.Errorf("Unexpected index page:\n%s", index)
+	}
+
+	project := read("web/index.html")
+	for _, want := range []string{
+		`<span class="prio p1">P1</span> Fix login`,
+		`overdue 2020-01-01`,
+		`<li class="task in-progress">`,
+		`href="../web/notes/design.html"`,
+		`done 2024-03-01`,
+	} {
+		if !strings.Contains(project, want) {
+			t.Errorf("Expected %q in project page", want)
+		}
+	}
+	if strings.Contains(project, "Salary") || strings.Contains(project, "Secret") {
+		t.Error("Private task or note was published")
+	}
+	if strings.Index(project, "In Progress") > strings.Index(project, "Done") {
+		t.Error("Expected In Progress group before Done")
+	}
+
+	note := read("web/notes/design.html")
+	if !strings.Contains(note, "<h1>Design</h1>") || strings.Contains(note, "title: Design") {
+		t.Errorf("Expected rendered note without front matter:\n%s", note)
+	}
+	if !strings.Contains(note, `<span class="wikilink broken">secret</span>`) {
+		t.Error("Expected link to private note to be left unresolved")
+	}
+
+	if _, err := os.Stat(filepath.Join(outDir, "web", "notes", "secret.html")); !os.IsNotExist(err) {
+		t.Error("Private note page should not be written")
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "web", AttachmentsDirName, "shot.png")); err != nil {
+		t.Errorf("Expected linked attachment to be copied: %v", err)
+	}
+	for _, name := range []string{"payslip.png", "unused.png"} {
+		if _, err := os.Stat(filepath.Join(outDir, "web", AttachmentsDirName, name)); !os.IsNotExist(err) {
+			t.Errorf("Attachment %s is not linked from published content and should not be copied", name)
+		}
+	}
+}
+
+func TestPublishPrivateAndStale(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	web := tb.AddProject("web")
+	tb.WriteFile(filepath.Join(web, "notes.md"), "---\ntags: [private]\n---\nProject secrets\n")
+	tb.WriteFile(filepath.Join(web, "notes", "plan.md"), "---\ntitle: Plan\n---\n![a](../attachments/a.png)\n")
+	tb.WriteFile(filepath.Join(web, AttachmentsDirName, "a.png"), "image")
+
+	outDir := filepath.Join(tb.TmpDir, "site")
+	tb.WriteFile(filepath.Join(outDir, "CNAME"), "example.com\n")
+	tb.WriteFile(filepath.Join(outDir, "blog", "post.html"), "<p>mine</p>")
+	tb.WriteFile(filepath.Join(outDir, "web", "about.html"), "<p>mine too</p>")
+	opts := PublishOptions{OutDir: outDir, PrivateTag: DefaultPrivateTag}
+	if _, err := Publish(tb.ActiveDirPath, opts); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	page, err := os.ReadFile(filepath.Join(outDir, "web", "index.html"))
+	if err != nil {
+		t.Fatalf("Failed to read project page: %v", err)
+	}
+	if strings.Contains(string(page), "Project secrets") {
+		t.Error("Private notes.md was published")
+	}
+
+	// A note marked private after publishing goes offline with its attachments
+	tb.WriteFile(filepath.Join(web, "notes", "plan.md"), "---\ntitle: Plan\ntags: [private]\n---\n![a](../attachments/a.png)\n")
+	result, err := Publish(tb.ActiveDirPath, opts)
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if result.Removed != 2 {
+		t.Errorf("Expected 2 stale files removed, got %d", result.Removed)
+	}
+	for _, rel := range []string{"web/notes/plan.html", "web/attachments/a.png", "web/notes", "web/attachments"} {
+		if tb.FileExists(filepath.Join(outDir, filepath.FromSlash(rel))) {
+			t.Errorf("Expected %s to be removed", rel)
+		}
+	}
+	// Only files an earlier run wrote are removed
+	for _, rel := range []string{"CNAME", "blog/post.html", "web/about.html", publishManifestFile} {
+		if !tb.FileExists(filepath.Join(outDir, filepath.FromSlash(rel))) {
+			t.Errorf("Expected %s to be kept", rel)
+		}
+	}
+}
+
+func TestPublishUnknownProject(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	tb.AddProject("web")
+
+	_, err := Publish(tb.ActiveDirPath, PublishOptions{OutDir: filepath.Join(tb.TmpDir, "site"), Projects: []string{"nope"}})
+	if err == nil {
+		t.Error("Expected error for unknown project")
+	}
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ParseRef splits a #ref: value into its path and line number
+// The line is 0 when the reference has no line ("README.md")
+func ParseRef(ref string) (string, int) {
+	idx := strings.LastIndex(ref, ":")
+	if idx <= 0 {
+		return ref, 0
+	}
+
+	line, err := strconv.Atoi(ref[idx+1:])
+	if err != nil || line < 0 {
+		return ref, 0
+	}
+
+	return ref[:idx], line
+}
+
+// ResolveRef finds the file a #ref: value points to
+// Relative paths are resolved against each linked repo, either as
+// "<repo>/<path>" or as a path inside the repo. Absolute paths are used as-is.
+func ResolveRef(ref string, repos []string) (string, int, error) {
+	path, line := ParseRef(ref)
+	if path == "" {
+		return "", 0, fmt.Errorf("empty reference")
+	}
+
+	if filepath.IsAbs(path) {
+		if _, err := os.Stat(path); err != nil {
+			return "", 0, fmt.Errorf("referenced file not found: %s", path)
+		}
+		return path, line, nil
+	}
+
+	path = filepath.FromSlash(path)
+	first, rest, _ := strings.Cut(path, string(filepath.Separator))
+
+	for _, repo := range repos {
+		var candidates []string
+		if first == filepath.Base(repo) && rest != "" {
+			candidates = append(candidates, filepath.Join(repo, rest))
+		}
+		candidates = append(candidates, filepath.Join(repo, path))
+
+		for _, candidate := range candidates {
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate, line, nil
+			}
+		}
+	}
+
+	return "", 0, fmt.Errorf("referenced file '%s' not found in linked repos", path)
+}
+
+// MakeRef builds a #ref: value for a file inside one of the linked repos
+// The reference has the form "<repo>/<path>[:line]"
+func MakeRef(filePath string, line int, repos []string) (string, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	for _, repo := range repos {
+		rel, err := filepath.Rel(repo, absPath)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			continue
+		}
+
+		ref := filepath.Base(repo) + "/" + filepath.ToSlash(rel)
+		if line > 0 {
+			ref = fmt.Sprintf("%s:%d", ref, line)
+		}
+		return ref, nil
+	}
+
+	return "", fmt.Errorf("%s is not inside a linked repository", filePath)
+}
+
+// SetTodoRef sets or clears the #ref: code reference of a todo item
+// An empty ref removes the existing reference
+func SetTodoRef(todo *TodoItem, ref string) error {
+	if strings.ContainsAny(ref, " \t") {
+		return fmt.Errorf("reference must not contain whitespace: %s", ref)
+	}
+
+	content, err := os.ReadFile(todo.File)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+
+	if todo.Line < 1 || todo.Line > len(lines) {
+		return fmt.Errorf("invalid line number: %d", todo.Line)
+	}
+
+	line := lines[todo.Line-1]
+	if !taskLinePattern.MatchString(line) {
+		return fmt.Errorf("line is not a valid todo item")
+	}
+
+	// Remove any existing #ref: tag
+	refPattern := regexp.MustCompile(`\s+#ref:\S+`)
+	line = refPattern.ReplaceAllString(line, "")
+
+	if ref != "" {
+		line = fmt.Sprintf("%s #ref:%s", line, ref)
+	}
+
+	lines[todo.Line-1] = line
+
+	return os.WriteFile(todo.File, []byte(strings.Join(lines, "\n")), 0644)
+}
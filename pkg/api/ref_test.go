@@ -0,0 +1,122 @@
+package api
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		ref          string
+		expectedPath string
+		expectedLine int
+	}{
+		{"api/server.go:42", "api/server.go", 42},
+		{"README.md", "README.md", 0},
+		{"weird:name.go", "weird:name.go", 0},
+	}
+
+	for _, tt := range tests {
+		path, line := ParseRef(tt.ref)
+		if path != tt.expectedPath || line != tt.expectedLine {
+			t.Errorf("ParseRef(%s): expected (%s, %d), got (%s, %d)", tt.ref, tt.expectedPath, tt.expectedLine, path, line)
+		}
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	apiRepo := filepath.Join(tb.TmpDir, "dev", "api")
+	web := filepath.Join(tb.TmpDir, "dev", "web")
+	tb.WriteFile(filepath.Join(apiRepo, "server.go"), "package api\n")
+	tb.WriteFile(filepath.Join(web, "src", "app.js"), "// app\n")
+	repos := []string{apiRepo, web}
+
+	tests := []struct {
+		ref          string
+		expectedPath string
+		expectedLine int
+	}{
+		{"api/server.go:42", filepath.Join(apiRepo, "server.go"), 42},
+		{"server.go:7", filepath.Join(apiRepo, "server.go"), 7},
+		{"src/app.js", filepath.Join(web, "src", "app.js"), 0},
+		{"web/src/app.js:3", filepath.Join(web, "src", "app.js"), 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			path, line, err := ResolveRef(tt.ref, repos)
+			if err != nil {
+				t.Fatalf("ResolveRef failed: %v", err)
+			}
+			if path != tt.expectedPath || line != tt.expectedLine {
+				t.Errorf("Expected (%s, %d), got (%s, %d)", tt.expectedPath, tt.expectedLine, path, line)
+			}
+		})
+	}
+
+	if _, _, err := ResolveRef("missing.go:1", repos); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestMakeRef(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	apiRepo := filepath.Join(tb.TmpDir, "dev", "api")
+	repos := []string{apiRepo}
+
+	ref, err := MakeRef(filepath.Join(apiRepo, "pkg", "server.go"), 12, repos)
+	if err != nil {
+		t.Fatalf("MakeRef failed: %v", err)
+	}
+	if ref != "api/pkg/server.go:12" {
+		t.Errorf("Expected 'api/pkg/server.go:12', got '%s'", ref)
+	}
+
+	if _, err := MakeRef(filepath.Join(tb.TmpDir, "elsewhere.go"), 1, repos); err == nil {
+		t.Error("Expected error for file outside linked repos")
+	}
+}
+
+func TestSetTodoRef(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	projectDir := tb.AddProject("backend")
+	todoPath := filepath.Join(projectDir, "todo.md")
+	tb.WriteFile(todoPath, "# Tasks\n\n## Active\n\n- [ ] Fix timeout #bug\n")
+
+	todos, err := ParseAllTodos(tb.ActiveDirPath, false)
+	if err != nil || len(todos) != 1 {
+		t.Fatalf("Expected 1 todo, got %d (%v)", len(todos), err)
+	}
+
+	if err := SetTodoRef(&todos[0], "api/server.go:42"); err != nil {
+		t.Fatalf("SetTodoRef failed: %v", err)
+	}
+
+	todos, _ = ParseAllTodos(tb.ActiveDirPath, false)
+	if todos[0].Ref != "api/server.go:42" || todos[0].Content != "Fix timeout" {
+		t.Errorf("Unexpected todo after setting ref: %+v", todos[0])
+	}
+
+	// Replacing keeps a single ref
+	if err := SetTodoRef(&todos[0], "api/server.go:50"); err != nil {
+		t.Fatalf("SetTodoRef failed: %v", err)
+	}
+	if content := tb.ReadFile(todoPath); strings.Count(content, "#ref:") != 1 || !strings.Contains(content, "#ref:api/server.go:50") {
+		t.Errorf("Expected single updated ref:\n%s", content)
+	}
+
+	todos, _ = ParseAllTodos(tb.ActiveDirPath, false)
+	if err := SetTodoRef(&todos[0], ""); err != nil {
+		t.Fatalf("SetTodoRef failed: %v", err)
+	}
+	if content := tb.ReadFile(todoPath); !strings.Contains(content, "- [ ] Fix timeout #bug\n") {
+		t.Errorf("Expected ref to be cleared:\n%s", content)
+	}
+}
@@ -0,0 +1,258 @@
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+)
+
+// Layouts for checkouts under the dev root
+const (
+	RepoLayoutFlat = "flat" // <root>/<name>
+	RepoLayoutHost = "host" // <root>/<host>/<org>/<name>
+)
+
+// RepoOptions controls where linked repositories are checked out
+type RepoOptions struct {
+	DevRoot string // Empty uses ~/dev
+	Layout  string // Empty uses RepoLayoutFlat
+}
+
+// LinkedRepo is a repository listed in a project's .repos file
+// A line is a git URL with optional path=<dir> and branch=<name> options:
+//
+//	git@github.com:acme/api.git path=~/work/acme-api branch=main
+type LinkedRepo struct {
+	URL    string `json:"url"`
+	Path   string `json:"path"`             // Local checkout
+	Branch string `json:"branch,omitempty"` // Branch to clone, empty for the remote's default
+}
+
+// Name returns the directory name of the checkout
+func (r LinkedRepo) Name() string {
+	return filepath.Base(r.Path)
+}
+
+// ValidRepoLayout reports whether layout is a known checkout layout
+func ValidRepoLayout(layout string) bool {
+	return layout == RepoLayoutFlat || layout == RepoLayoutHost
+}
+
+// DefaultDevRoot returns ~/dev
+func DefaultDevRoot() string {
+	return filepath.Join(os.Getenv("HOME"), "dev")
+}
+
+// devRoot returns the expanded dev root of the options
+func (o RepoOptions) devRoot() (string, error) {
+	if o.DevRoot == "" {
+		return DefaultDevRoot(), nil
+	}
+	root, err := fileutil.ExpandPath(o.DevRoot)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand dev root: %w", err)
+	}
+	return root, nil
+}
+
+// RepoCheckoutPath returns where a repository is checked out by default
+// The host layout falls back to <root>/<name> for local paths.
+func RepoCheckoutPath(gitURL string, opts RepoOptions) (string, error) {
+	root, err := opts.devRoot()
+	if err != nil {
+		return "", err
+	}
+
+	name := ExtractRepoName(gitURL)
+	if name == "" {
+		return "", fmt.Errorf("could not determine repository name from %s", gitURL)
+	}
+
+	if opts.Layout == RepoLayoutHost {
+		if host, org := SplitRepoURL(gitURL); host != "" {
+			return filepath.Join(root, host, filepath.FromSlash(org), name), nil
+		}
+	}
+	return filepath.Join(root, name), nil
+}
+
+// SplitRepoURL returns the host and organization of a git URL
+// The organization keeps nested groups (e.g. "group/subgroup"). Both are empty
+// for local paths.
+func SplitRepoURL(gitURL string) (host, org string) {
+	var repoPath string
+	if strings.Contains(gitURL, "://") {
+		u, err := url.Parse(gitURL)
+		if err != nil || u.Scheme == "file" {
+			return "", ""
+		}
+		host, repoPath = u.Hostname(), u.Path
+	} else {
+		// scp-like syntax: [user@]host:org/name.git
+		hostPart, rest, found := strings.Cut(gitURL, ":")
+		if !found || strings.Contains(hostPart, "/") {
+			return "", ""
+		}
+		if _, h, ok := strings.Cut(hostPart, "@"); ok {
+			hostPart = h
+		}
+		host, repoPath = hostPart, rest
+	}
+
+	repoPath = strings.Trim(strings.TrimSuffix(strings.TrimSuffix(repoPath, "/"), ".git"), "/")
+	if i := strings.LastIndex(repoPath, "/"); i >= 0 {
+		org = repoPath[:i]
+	}
+	return host, org
+}
+
+// ParseRepoLine parses a .repos line into the URL and its key=value options
+// Empty lines and comments return an empty URL.
+func ParseRepoLine(line string) (string, map[string]string, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return "", nil, nil
+	}
+
+	options := make(map[string]string)
+	for _, field := range fields[1:] {
+		key, value, found := strings.Cut(field, "=")
+		if !found || value == "" {
+			return "", nil, fmt.Errorf("invalid option '%s' (expected key=value)", field)
+		}
+		switch key {
+		case "path", "branch":
+			options[key] = value
+		default:
+			return "", nil, fmt.Errorf("unknown option '%s' (expected path or branch)", key)
+		}
+	}
+
+	return fields[0], options, nil
+}
+
+// LoadLinkedRepos reads a project's .repos file and resolves the checkout paths
+// Relative path= options are relative to the dev root. Lines that cannot be
+// parsed or resolved are skipped and returned as warnings, so one bad line
+// does not hide the other repositories.
+func LoadLinkedRepos(projectDir string, opts RepoOptions) ([]LinkedRepo, []string, error) {
+	reposFile := filepath.Join(projectDir, ".repos")
+
+	file, err := os.Open(reposFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []LinkedRepo{}, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to open .repos file: %w", err)
+	}
+	defer file.Close()
+
+	var repos []LinkedRepo
+	var warnings []string
+	lineNum := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNum++
+		repo, ok, err := parseLinkedRepo(scanner.Text(), opts)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf(".repos line %d: %v (skipped)", lineNum, err))
+			continue
+		}
+		if ok {
+			repos = append(repos, repo)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read .repos file: %w", err)
+	}
+
+	return repos, warnings, nil
+}
+
+// parseLinkedRepo parses a .repos line and resolves its checkout path
+// Returns ok=false for empty lines and comments
+func parseLinkedRepo(line string, opts RepoOptions) (LinkedRepo, bool, error) {
+	gitURL, options, err := ParseRepoLine(line)
+	if err != nil || gitURL == "" {
+		return LinkedRepo{}, false, err
+	}
+
+	repo := LinkedRepo{URL: gitURL, Branch: options["branch"]}
+	path, ok := options["path"]
+	if !ok {
+		repo.Path, err = RepoCheckoutPath(gitURL, opts)
+		return repo, err == nil, err
+	}
+
+	repo.Path, err = fileutil.ExpandPath(path)
+	if err != nil {
+		return LinkedRepo{}, false, fmt.Errorf("failed to expand path: %w", err)
+	}
+	if !filepath.IsAbs(repo.Path) {
+		root, err := opts.devRoot()
+		if err != nil {
+			return LinkedRepo{}, false, err
+		}
+		repo.Path = filepath.Join(root, repo.Path)
+	}
+	return repo, true, nil
+}
+
+// GetLinkedRepos returns the list of linked repository paths for a project
+// Warnings are those of LoadLinkedRepos
+func GetLinkedRepos(projectDir string, opts RepoOptions) ([]string, []string, error) {
+	repos, warnings, err := LoadLinkedRepos(projectDir, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paths := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		paths = append(paths, repo.Path)
+	}
+	return paths, warnings, nil
+}
+
+// AddRepoLink adds a git URL to the project's .repos file
+// path and branch are optional. A URL that is already linked is left unchanged.
+func AddRepoLink(projectDir, gitURL, path, branch string) error {
+	reposFile := filepath.Join(projectDir, ".repos")
+
+	// Check if already linked
+	if data, err := os.ReadFile(reposFile); err == nil {
+		for _, line := range strings.Split(string(data), "\n") {
+			if linked, _, err := ParseRepoLine(line); err == nil && linked == gitURL {
+				return nil
+			}
+		}
+	}
+
+	line := gitURL
+	for key, value := range map[string]string{"path": path, "branch": branch} {
+		if strings.ContainsAny(value, " \t") {
+			return fmt.Errorf("%s must not contain whitespace: %s", key, value)
+		}
+	}
+	if path != "" {
+		line += " path=" + path
+	}
+	if branch != "" {
+		line += " branch=" + branch
+	}
+
+	// Append to file
+	f, err := os.OpenFile(reposFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open .repos file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintln(f, line)
+	return err
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestSplitRepoURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		host string
+		org  string
+	}{
+		{"git@github.com:acme/api.git", "github.com", "acme"},
+		{"https://github.com/acme/api.git", "github.com", "acme"},
+		{"https://gitlab.com/group/sub/api", "gitlab.com", "group/sub"},
+		{"ssh://git@git.example.com:2222/team/api.git", "git.example.com", "team"},
+		{"github.com:api.git", "github.com", ""},
+		{"/local/path/to/api", "", ""},
+		{"file:///srv/git/api.git", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			host, org := SplitRepoURL(tt.url)
+			if host != tt.host || org != tt.org {
+				t.Errorf("Expected %q %q, got %q %q", tt.host, tt.org, host, org)
+			}
+		})
+	}
+}
+
+func TestRepoCheckoutPath(t *testing.T) {
+	root := t.TempDir()
+	flat := RepoOptions{DevRoot: root}
+	host := RepoOptions{DevRoot: root, Layout: RepoLayoutHost}
+
+	tests := []struct {
+		url      string
+		opts     RepoOptions
+		expected string
+	}{
+		{"git@github.com:acme/api.git", flat, filepath.Join(root, "api")},
+		{"git@github.com:acme/api.git", host, filepath.Join(root, "github.com", "acme", "api")},
+		{"https://github.com/other/api", host, filepath.Join(root, "github.com", "other", "api")},
+		{"/local/path/to/api", host, filepath.Join(root, "api")},
+		{"git@github.com:acme/api.git", RepoOptions{}, filepath.Join(os.Getenv("HOME"), "dev", "api")},
+	}
+
+	for _, tt := range tests {
+		got, err := RepoCheckoutPath(tt.url, tt.opts)
+		if err != nil {
+			t.Fatalf("RepoCheckoutPath(%s) failed: %v", tt.url, err)
+		}
+		if got != tt.expected {
+			t.Errorf("RepoCheckoutPath(%s, %s): expected %q, got %q", tt.url, tt.opts.Layout, tt.expected, got)
+		}
+	}
+}
+
+func TestLoadLinkedRepos(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	projectDir := tb.AddProject("web")
+	root := filepath.Join(tb.TmpDir, "code")
+	work := filepath.Join(tb.TmpDir, "work", "acme-api")
+
+	tb.WriteFile(filepath.Join(projectDir, ".repos"), `git@github.com:acme/api.git path=`+work+` branch=main
+# Comment
+https://github.com/other/api.git   branch=develop
+git@github.com:acme/web.git path=clients/web
+`)
+
+	repos, warnings, err := LoadLinkedRepos(projectDir, RepoOptions{DevRoot: root, Layout: RepoLayoutHost})
+	if err != nil || len(warnings) != 0 {
+		t.Fatalf("LoadLinkedRepos failed: %v %v", err, warnings)
+	}
+
+	expected := []LinkedRepo{
+		{URL: "git@github.com:acme/api.git", Path: work, Branch: "main"},
+		{URL: "https://github.com/other/api.git", Path: filepath.Join(root, "github.com", "other", "api"), Branch: "develop"},
+		{URL: "git@github.com:acme/web.git", Path: filepath.Join(root, "clients", "web")},
+	}
+	if len(repos) != len(expected) {
+		t.Fatalf("Expected %d repos, got %+v", len(expected), repos)
+	}
+	for i, repo := range repos {
+		if repo != expected[i] {
+			t.Errorf("Repo %d: expected %+v, got %+v", i, expected[i], repo)
+		}
+	}
+
+	// Bad lines are skipped with a warning, the rest still loads
+	tb.WriteFile(filepath.Join(projectDir, ".repos"), "git@github.com:acme/api.git\ngit@github.com:acme/web.git dir=x\ngit@github.com:acme/cli.git\n")
+	repos, warnings, err = LoadLinkedRepos(projectDir, RepoOptions{DevRoot: root})
+	if err != nil {
+		t.Fatalf("LoadLinkedRepos failed: %v", err)
+	}
+	if len(repos) != 2 || repos[1].URL != "git@github.com:acme/cli.git" {
+		t.Errorf("Expected api and cli repos, got %+v", repos)
+	}
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], ".repos line 2: unknown option 'dir'") {
+		t.Errorf("Expected warning for line 2, got %v", warnings)
+	}
+}
+
+func TestAddRepoLink(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	projectDir := tb.AddProject("web")
+	reposFile := filepath.Join(projectDir, ".repos")
+	tb.WriteFile(reposFile, "git@github.com:acme/api.git path=~/work/api\n")
+
+	if err := AddRepoLink(projectDir, "git@github.com:acme/api.git", "", ""); err != nil {
+		t.Fatalf("AddRepoLink failed: %v", err)
+	}
+	if err := AddRepoLink(projectDir, "git@github.com:acme/web.git", "~/work/web", "main"); err != nil {
+		t.Fatalf("AddRepoLink failed: %v", err)
+	}
+	if err := AddRepoLink(projectDir, "git@github.com:acme/cli.git", "my dir", ""); err == nil {
+		t.Error("Expected error for path with whitespace")
+	}
+
+	data, err := os.ReadFile(reposFile)
+	if err != nil {
+		t.Fatalf("Failed to read .repos: %v", err)
+	}
+	expected := "git@github.com:acme/api.git path=~/work/api\ngit@github.com:acme/web.git path=~/work/web branch=main\n"
+	if string(data) != expected {
+		t.Errorf("Unexpected .repos:\n%q", string(data))
+	}
+}
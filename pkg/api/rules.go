@@ -0,0 +1,157 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/markdown"
+)
+
+// RefileTargetTrash is the rule target that deletes matching items from the dump
+const RefileTargetTrash = "[TRASH]"
+
+// RefileRule routes matching dump items to a project (or the trash)
+//
+// Rules are read from the brain's refile-rules file, one per line:
+//
+//	tag:infra          -> infra
+//	keyword:invoice    -> admin
+//	regex:^call\s      -> phone
+//	tag:spam           -> [TRASH]
+//
+// Blank lines and lines starting with # are ignored. The first matching rule wins.
+type RefileRule struct {
+	Kind    string // "tag", "keyword" or "regex"
+	Pattern string
+	Target  string // Project name or RefileTargetTrash
+	Line    int    // Line number in the rules file
+	re      *regexp.Regexp
+}
+
+// LoadRefileRules reads refile rules from a file
+// A missing file is not an error and yields no rules
+func LoadRefileRules(path string) ([]RefileRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []RefileRule{}, nil
+		}
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	return ParseRefileRules(string(data))
+}
+
+// splitRuleLine splits a rule line into matcher and target at the last "->"
+// Patterns may contain "->" themselves (e.g. regex:a->b -> proj); project
+// names cannot.
+func splitRuleLine(line string) (matcher, target string, ok bool) {
+	i := strings.LastIndex(line, "->")
+	if i < 0 {
+		return line, "", false
+	}
+	return line[:i], line[i+len("->"):], true
+}
+
+// ParseRefileRules parses the contents of a refile rules file
+func ParseRefileRules(content string) ([]RefileRule, error) {
+	var rules []RefileRule
+
+	for i, line := range strings.Split(content, "\n") {
+		lineNum := i + 1
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		matcher, target, ok := splitRuleLine(line)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected '<kind>:<pattern> -> <target>'", lineNum)
+		}
+
+		if !strings.Contains(matcher, ":") {
+			return nil, fmt.Errorf("line %d: expected '<kind>:<pattern>' before '->'", lineNum)
+		}
+
+		target = strings.TrimSpace(target)
+		if target == "" {
+			return nil, fmt.Errorf("line %d: missing target", lineNum)
+		}
+
+		rule, err := ParseMatcher(matcher)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		rule.Target = target
+		rule.Line = lineNum
+
+		rules = append(rules, *rule)
+	}
+
+	return rules, nil
+}
+
+// ParseMatcher parses a '<kind>:<pattern>' expression (tag, keyword or regex)
+// It is the left-hand side of a refile rule, also used to select tasks and notes
+func ParseMatcher(expr string) (*RefileRule, error) {
+	kind, pattern, ok := strings.Cut(strings.TrimSpace(expr), ":")
+	if !ok || strings.TrimSpace(pattern) == "" {
+		return nil, fmt.Errorf("expected '<kind>:<pattern>', got '%s'", expr)
+	}
+
+	rule := &RefileRule{
+		Kind:    strings.ToLower(strings.TrimSpace(kind)),
+		Pattern: strings.TrimSpace(pattern),
+	}
+
+	switch rule.Kind {
+	case "tag":
+		rule.Pattern = strings.TrimPrefix(rule.Pattern, "#")
+	case "keyword":
+	case "regex":
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex: %w", err)
+		}
+		rule.re = re
+	default:
+		return nil, fmt.Errorf("unknown rule kind '%s' (must be: tag, keyword, regex)", rule.Kind)
+	}
+
+	return rule, nil
+}
+
+// Matches reports whether the rule applies to a dump item
+// Rules are matched against the item content without its #captured: timestamp
+func (r *RefileRule) Matches(item markdown.DumpItem) bool {
+	content, _ := markdown.ExtractTimestamp(item.Content)
+
+	switch r.Kind {
+	case "tag":
+		_, tags := markdown.ExtractTags(content)
+		for _, tag := range tags {
+			if strings.EqualFold(tag, r.Pattern) {
+				return true
+			}
+		}
+		return false
+	case "keyword":
+		return strings.Contains(strings.ToLower(content), strings.ToLower(r.Pattern))
+	case "regex":
+		return r.re != nil && r.re.MatchString(content)
+	}
+
+	return false
+}
+
+// MatchRefileRule returns the first rule that matches the item, or nil
+func MatchRefileRule(rules []RefileRule, item markdown.DumpItem) *RefileRule {
+	for i := range rules {
+		if rules[i].Matches(item) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
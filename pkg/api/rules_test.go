@@ -0,0 +1,114 @@
+package api
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/sandermoonemans/local-brain/pkg/markdown"
+)
+
+func TestParseRefileRules(t *testing.T) {
+	content := `# Refile rules
+tag:#infra -> infra
+
+keyword:invoice -> admin
+regex:^call\s -> phone
+tag:spam -> [TRASH]
+regex:a->b -> arrows
+`
+
+	rules, err := ParseRefileRules(content)
+	if err != nil {
+		t.Fatalf("ParseRefileRules failed: %v", err)
+	}
+
+	if len(rules) != 5 {
+		t.Fatalf("Expected 5 rules, got %d", len(rules))
+	}
+
+	if rules[0].Kind != "tag" || rules[0].Pattern != "infra" || rules[0].Target != "infra" || rules[0].Line != 2 {
+		t.Errorf("Unexpected first rule: %+v", rules[0])
+	}
+	if rules[3].Target != RefileTargetTrash {
+		t.Errorf("Expected trash target, got '%s'", rules[3].Target)
+	}
+	// The target follows the last "->", so patterns may contain arrows
+	if rules[4].Pattern != "a->b" || rules[4].Target != "arrows" {
+		t.Errorf("Unexpected arrow rule: %+v", rules[4])
+	}
+}
+
+func TestParseRefileRules_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"missing arrow", "tag:infra infra"},
+		{"missing pattern", "tag: -> infra"},
+		{"missing target", "tag:infra ->"},
+		{"unknown kind", "glob:*.go -> code"},
+		{"invalid regex", "regex:([a- -> code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseRefileRules(tt.content); err == nil {
+				t.Errorf("Expected error for %q", tt.content)
+			}
+		})
+	}
+}
+
+func TestMatchRefileRule(t *testing.T) {
+	rules, err := ParseRefileRules(`tag:infra -> infra
+keyword:invoice -> admin
+regex:^[Cc]all -> phone
+tag:spam -> [TRASH]`)
+	if err != nil {
+		t.Fatalf("ParseRefileRules failed: %v", err)
+	}
+
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{"Rotate certificates #infra #captured:2024-01-01", "infra"},
+		{"Rotate certificates #INFRA", "infra"},
+		{"Pay the Invoice for hosting", "admin"},
+		{"Call the bank", "phone"},
+		{"Buy now #spam", RefileTargetTrash},
+		{"Read a book", ""},
+		{"Mention infrastructure without tag", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			item := markdown.DumpItem{Type: markdown.ItemTypeTodo, Content: tt.content}
+			rule := MatchRefileRule(rules, item)
+
+			if tt.expected == "" {
+				if rule != nil {
+					t.Errorf("Expected no match, got rule on line %d", rule.Line)
+				}
+				return
+			}
+
+			if rule == nil {
+				t.Fatalf("Expected match for '%s'", tt.content)
+			}
+			if rule.Target != tt.expected {
+				t.Errorf("Expected target '%s', got '%s'", tt.expected, rule.Target)
+			}
+		})
+	}
+}
+
+func TestLoadRefileRules_NoFile(t *testing.T) {
+	rules, err := LoadRefileRules(filepath.Join(t.TempDir(), "refile-rules"))
+	if err != nil {
+		t.Fatalf("LoadRefileRules failed: %v", err)
+	}
+	if len(rules) != 0 {
+		t.Errorf("Expected no rules, got %d", len(rules))
+	}
+}
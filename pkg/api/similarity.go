@@ -0,0 +1,366 @@
+package api
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+)
+
+const (
+	similarityIndexFile = ".refile-index.json"
+	refileHistoryFile   = ".refile-history"
+
+	tagWeight     = 3 // #tags say more about a destination than plain words
+	historyWeight = 2 // Past refile decisions count more than project content
+)
+
+// ProjectSuggestion is a candidate refile destination with its similarity score (0-1)
+type ProjectSuggestion struct {
+	Project string
+	Score   float64
+}
+
+// SimilarityIndex is a local TF-IDF model over project content and refile history
+// The index is cached per brain (see refileStatePath) and only re-reads
+// projects whose files changed
+type SimilarityIndex struct {
+	Projects      map[string]*projectTerms  `json:"projects"`
+	History       map[string]map[string]int `json:"history"`
+	HistoryOffset int64                     `json:"history_offset"`
+	brainPath     string
+}
+
+type projectTerms struct {
+	Signature string         `json:"signature"`
+	Terms     map[string]int `json:"terms"`
+}
+
+// LoadSimilarityIndex loads the cached index for a brain
+// A missing or unreadable cache yields an empty index that will be rebuilt on Update
+func LoadSimilarityIndex(brainPath string) *SimilarityIndex {
+	idx := &SimilarityIndex{brainPath: brainPath}
+
+	if data, err := os.ReadFile(refileStatePath(brainPath, similarityIndexFile)); err == nil {
+		if json.Unmarshal(data, idx) != nil {
+			idx = &SimilarityIndex{brainPath: brainPath}
+		}
+	}
+
+	if idx.Projects == nil {
+		idx.Projects = make(map[string]*projectTerms)
+	}
+	if idx.History == nil {
+		idx.History = make(map[string]map[string]int)
+	}
+
+	return idx
+}
+
+// Update refreshes the index for the given projects
+// Only projects whose todo.md or notes changed are re-read, and only new
+// refile history entries are consumed
+func (idx *SimilarityIndex) Update(activeDir string, projects []string) error {
+	present := make(map[string]bool)
+
+	dirs := make(map[string]string)
+	if found, err := listProjectDirs(activeDir); err == nil {
+		for _, dir := range found {
+			dirs[dir.Name] = dir.Path
+		}
+	}
+
+	for _, project := range projects {
+		present[project] = true
+		projectDir, ok := dirs[project]
+		if !ok {
+			projectDir = filepath.Join(activeDir, project)
+		}
+		files := projectSourceFiles(projectDir)
+		signature := fileSignature(files)
+
+		if cached, ok := idx.Projects[project]; ok && cached.Signature == signature {
+			continue
+		}
+
+		terms := make(map[string]int)
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				continue
+			}
+			addTerms(terms, string(data))
+		}
+
+		idx.Projects[project] = &projectTerms{Signature: signature, Terms: terms}
+	}
+
+	// Drop projects that no longer exist
+	for project := range idx.Projects {
+		if !present[project] {
+			delete(idx.Projects, project)
+		}
+	}
+
+	return idx.readNewHistory()
+}
+
+// Rank scores every indexed project against the text, best match first
+// Projects with equal scores are ordered alphabetically
+func (idx *SimilarityIndex) Rank(text string) []ProjectSuggestion {
+	docs := make(map[string]map[string]int)
+	for project, pt := range idx.Projects {
+		doc := make(map[string]int)
+		for term, count := range pt.Terms {
+			doc[term] += count
+		}
+		for term, count := range idx.History[project] {
+			doc[term] += count * historyWeight
+		}
+		docs[project] = doc
+	}
+
+	// Document frequency per term
+	df := make(map[string]int)
+	for _, doc := range docs {
+		for term := range doc {
+			df[term]++
+		}
+	}
+
+	n := float64(len(docs))
+	idf := func(term string) float64 {
+		return math.Log((n+1)/(float64(df[term])+1)) + 1
+	}
+
+	query := make(map[string]int)
+	addTerms(query, text)
+
+	queryVec := make(map[string]float64)
+	var queryNorm float64
+	for term, count := range query {
+		w := float64(count) * idf(term)
+		queryVec[term] = w
+		queryNorm += w * w
+	}
+	queryNorm = math.Sqrt(queryNorm)
+
+	suggestions := make([]ProjectSuggestion, 0, len(docs))
+	for project, doc := range docs {
+		var dot, docNorm float64
+		for term, count := range doc {
+			w := float64(count) * idf(term)
+			docNorm += w * w
+			if qw, ok := queryVec[term]; ok {
+				dot += w * qw
+			}
+		}
+
+		score := 0.0
+		if dot > 0 && queryNorm > 0 && docNorm > 0 {
+			score = dot / (queryNorm * math.Sqrt(docNorm))
+		}
+		suggestions = append(suggestions, ProjectSuggestion{Project: project, Score: score})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Project < suggestions[j].Project
+	})
+
+	return suggestions
+}
+
+// Save writes the index cache
+func (idx *SimilarityIndex) Save() error {
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("failed to marshal similarity index: %w", err)
+	}
+	path := refileStatePath(idx.brainPath, similarityIndexFile)
+	if err := fileutil.EnsureDir(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return fileutil.AtomicWriteFile(path, data)
+}
+
+// refileStatePath returns where a brain's refile index or history file is kept
+// They are machine-local state, so they live outside the brain (which may be
+// synced or under git) in <user cache>/local-brain/<brain>-<hash>; BRAIN_CACHE_DIR
+// overrides <user cache>/local-brain. Files left in the brain root by older
+// versions are moved there on first use.
+func refileStatePath(brainPath, name string) string {
+	base := os.Getenv("BRAIN_CACHE_DIR")
+	if base == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return filepath.Join(brainPath, name)
+		}
+		base = filepath.Join(cacheDir, "local-brain")
+	}
+
+	absBrain, err := filepath.Abs(brainPath)
+	if err != nil {
+		absBrain = brainPath
+	}
+	sum := sha256.Sum256([]byte(absBrain))
+	path := filepath.Join(base, filepath.Base(absBrain)+"-"+hex.EncodeToString(sum[:4]), name)
+
+	legacy := filepath.Join(brainPath, name)
+	if fileutil.FileExists(legacy) && !fileutil.FileExists(path) {
+		if data, err := os.ReadFile(legacy); err == nil && fileutil.EnsureDir(filepath.Dir(path)) == nil {
+			if fileutil.AtomicWriteFile(path, data) == nil {
+				os.Remove(legacy)
+			}
+		}
+	}
+	return path
+}
+
+// AppendRefileHistory records that content was refiled to a project
+// The history is used by SimilarityIndex to learn from past decisions
+func AppendRefileHistory(brainPath, project, content string) error {
+	historyPath := refileStatePath(brainPath, refileHistoryFile)
+	if err := fileutil.EnsureDir(filepath.Dir(historyPath)); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	f, err := os.OpenFile(historyPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open refile history: %w", err)
+	}
+	defer f.Close()
+
+	content = strings.ReplaceAll(content, "\t", " ")
+	_, err = fmt.Fprintf(f, "%s\t%s\t%s\n", time.Now().Format("2006-01-02"), project, content)
+	return err
+}
+
+// readNewHistory consumes history entries appended since the last update
+func (idx *SimilarityIndex) readNewHistory() error {
+	f, err := os.Open(refileStatePath(idx.brainPath, refileHistoryFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to open refile history: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	// History was truncated or replaced: start over
+	if info.Size() < idx.HistoryOffset {
+		idx.History = make(map[string]map[string]int)
+		idx.HistoryOffset = 0
+	}
+
+	if _, err := f.Seek(idx.HistoryOffset, io.SeekStart); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			// Leave incomplete trailing lines for the next update
+			break
+		}
+		idx.HistoryOffset += int64(len(line))
+
+		parts := strings.SplitN(strings.TrimSuffix(line, "\n"), "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		project := parts[1]
+		if idx.History[project] == nil {
+			idx.History[project] = make(map[string]int)
+		}
+		addTerms(idx.History[project], parts[2])
+	}
+
+	return nil
+}
+
+// projectSourceFiles returns the files that describe a project's content
+func projectSourceFiles(projectDir string) []string {
+	files := []string{
+		filepath.Join(projectDir, "todo.md"),
+		filepath.Join(projectDir, "notes.md"),
+	}
+	notes, _ := filepath.Glob(filepath.Join(projectDir, "notes", "*.md"))
+	sort.Strings(notes)
+	return append(files, notes...)
+}
+
+// fileSignature summarizes file names, sizes and mtimes to detect changes cheaply
+func fileSignature(files []string) string {
+	var sb strings.Builder
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s:%d:%d;", filepath.Base(file), info.Size(), info.ModTime().UnixNano())
+	}
+	return sb.String()
+}
+
+// stopWords are common words that carry no signal about a destination
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "that": true, "this": true,
+	"from": true, "are": true, "was": true, "but": true, "not": true, "you": true,
+	"all": true, "can": true, "has": true, "have": true, "into": true, "about": true,
+	"captured": true, "due": true, "tasks": true, "notes": true, "active": true,
+	"completed": true, "created": true, "updated": true, "title": true, "tags": true,
+	"aliases": true,
+}
+
+// addTerms tokenizes text into lowercase terms and adds them to counts
+// Hashtags are weighted higher; numbers, short words and stop words are skipped
+func addTerms(counts map[string]int, text string) {
+	for _, field := range strings.Fields(text) {
+		isTag := strings.HasPrefix(field, "#")
+
+		words := strings.FieldsFunc(strings.ToLower(field), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+
+		for _, word := range words {
+			if len(word) < 3 || stopWords[word] || isNumeric(word) {
+				continue
+			}
+			if isTag {
+				counts[word] += tagWeight
+			} else {
+				counts[word]++
+			}
+		}
+	}
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
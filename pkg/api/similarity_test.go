@@ -0,0 +1,147 @@
+package api
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestSimilarityIndex_Rank(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	tb.AddProject("infra")
+	tb.AddProject("finance")
+	tb.WriteFile(filepath.Join(tb.ActiveDirPath, "infra", "todo.md"), `# Tasks
+
+- [ ] Rotate TLS certificates on the load balancer #infra
+- [ ] Upgrade kubernetes cluster
+`)
+	tb.WriteFile(filepath.Join(tb.ActiveDirPath, "finance", "notes", "2024-01-01-budget.md"), `# Budget
+
+Invoice processing and quarterly budget review.
+`)
+
+	idx := LoadSimilarityIndex(tb.BrainPath)
+	if err := idx.Update(tb.ActiveDirPath, []string{"infra", "finance"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	ranked := idx.Rank("Renew certificates for kubernetes ingress")
+	if len(ranked) != 2 {
+		t.Fatalf("Expected 2 suggestions, got %d", len(ranked))
+	}
+	if ranked[0].Project != "infra" {
+		t.Errorf("Expected infra first, got %s", ranked[0].Project)
+	}
+	if ranked[0].Score <= 0 || ranked[0].Score > 1 {
+		t.Errorf("Expected score in (0, 1], got %f", ranked[0].Score)
+	}
+	if ranked[1].Score != 0 {
+		t.Errorf("Expected zero score for finance, got %f", ranked[1].Score)
+	}
+
+	ranked = idx.Rank("Pay the hosting invoice")
+	if ranked[0].Project != "finance" {
+		t.Errorf("Expected finance first, got %s", ranked[0].Project)
+	}
+}
+
+func TestSimilarityIndex_History(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	tb.AddProject("alpha")
+	tb.AddProject("beta")
+
+	idx := LoadSimilarityIndex(tb.BrainPath)
+	if err := idx.Update(tb.ActiveDirPath, []string{"alpha", "beta"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	// No signal yet: alphabetical with zero scores
+	ranked := idx.Rank("dentist appointment")
+	if ranked[0].Project != "alpha" || ranked[0].Score != 0 {
+		t.Errorf("Expected alpha with zero score, got %+v", ranked[0])
+	}
+
+	if err := AppendRefileHistory(tb.BrainPath, "beta", "Book dentist appointment"); err != nil {
+		t.Fatalf("AppendRefileHistory failed: %v", err)
+	}
+
+	if err := idx.Update(tb.ActiveDirPath, []string{"alpha", "beta"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	ranked = idx.Rank("dentist appointment")
+	if ranked[0].Project != "beta" || ranked[0].Score == 0 {
+		t.Errorf("Expected beta with positive score after history, got %+v", ranked[0])
+	}
+}
+
+func TestSimilarityIndex_SaveAndReload(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	tb.AddProject("alpha")
+	if err := AppendRefileHistory(tb.BrainPath, "alpha", "kubernetes upgrade"); err != nil {
+		t.Fatalf("AppendRefileHistory failed: %v", err)
+	}
+
+	idx := LoadSimilarityIndex(tb.BrainPath)
+	if err := idx.Update(tb.ActiveDirPath, []string{"alpha"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := idx.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded := LoadSimilarityIndex(tb.BrainPath)
+	if reloaded.HistoryOffset != idx.HistoryOffset {
+		t.Errorf("Expected history offset %d, got %d", idx.HistoryOffset, reloaded.HistoryOffset)
+	}
+
+	// Updating again must not count the same history twice
+	if err := reloaded.Update(tb.ActiveDirPath, []string{"alpha"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if reloaded.History["alpha"]["kubernetes"] != 1 {
+		t.Errorf("Expected history count 1, got %d", reloaded.History["alpha"]["kubernetes"])
+	}
+
+	// Removed projects are dropped from the index
+	if err := reloaded.Update(tb.ActiveDirPath, []string{}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, ok := reloaded.Projects["alpha"]; ok {
+		t.Error("Expected alpha to be dropped from the index")
+	}
+}
+
+func TestRefileStatePath(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	// State from older versions in the brain root moves to the cache
+	legacy := filepath.Join(tb.BrainPath, refileHistoryFile)
+	tb.WriteFile(legacy, "2024-01-01\talpha\tkubernetes upgrade\n")
+
+	path := refileStatePath(tb.BrainPath, refileHistoryFile)
+	if strings.HasPrefix(path, tb.BrainPath+string(filepath.Separator)) {
+		t.Errorf("Expected state outside the brain, got %s", path)
+	}
+	if tb.FileExists(legacy) || tb.ReadFile(path) != "2024-01-01\talpha\tkubernetes upgrade\n" {
+		t.Errorf("Expected legacy history to be moved to %s", path)
+	}
+
+	if err := AppendRefileHistory(tb.BrainPath, "beta", "dentist"); err != nil {
+		t.Fatalf("AppendRefileHistory failed: %v", err)
+	}
+	if tb.FileExists(legacy) || !strings.Contains(tb.ReadFile(path), "\tbeta\tdentist\n") {
+		t.Error("Expected history to be appended in the cache")
+	}
+
+	// Brains with the same directory name do not share state
+	other := filepath.Join(tb.TmpDir, "elsewhere", filepath.Base(tb.BrainPath))
+	if refileStatePath(other, refileHistoryFile) == path {
+		t.Error("Expected separate state per brain path")
+	}
+}
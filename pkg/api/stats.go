@@ -0,0 +1,145 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/dateutil"
+)
+
+// DefaultStatsWeeks is the number of weeks of history in project stats
+const DefaultStatsWeeks = 8
+
+// ProjectStats summarizes the tasks of one project (or all projects)
+type ProjectStats struct {
+	Project        string      `json:"project"` // Empty for brain-wide stats
+	Open           int         `json:"open"`
+	InProgress     int         `json:"in_progress"`
+	Blocked        int         `json:"blocked"`
+	Done           int         `json:"done"`
+	Overdue        []TodoItem  `json:"overdue"`
+	AvgOpenAgeDays float64     `json:"avg_open_age_days"` // Over unfinished tasks with a #captured: date
+	UndatedOpen    int         `json:"undated_open"`      // Unfinished tasks without a #captured: date
+	Weeks          []WeekStats `json:"weeks"`
+}
+
+// WeekStats is one week of task history, weeks start on Monday
+type WeekStats struct {
+	Start     string `json:"start"`     // YYYY-MM-DD of the Monday
+	Added     int    `json:"added"`     // Tasks captured during the week
+	Completed int    `json:"completed"` // Tasks with a #done: date during the week
+	Scope     int    `json:"scope"`     // Tasks that existed at the end of the week
+	Remaining int    `json:"remaining"` // Of those, tasks not yet done at the end of the week
+}
+
+// Unfinished returns the number of open, in-progress and blocked tasks
+func (s ProjectStats) Unfinished() int {
+	return s.Open + s.InProgress + s.Blocked
+}
+
+// ComputeStats computes task statistics over the last weeks, including the current one
+// todos must include completed tasks. Tasks without a #captured: date are treated as
+// existing since before the first week; done tasks without a #done: date are counted
+// in Done but left out of the weekly history since their completion week is unknown.
+func ComputeStats(project string, todos []TodoItem, now time.Time, weeks int) ProjectStats {
+	stats := ProjectStats{Project: project, Overdue: []TodoItem{}}
+	today := now.Format("2006-01-02")
+
+	var ageTotal, aged int
+	for _, todo := range todos {
+		switch todo.Status {
+		case "open":
+			stats.Open++
+		case "in-progress":
+			stats.InProgress++
+		case "blocked":
+			stats.Blocked++
+		case "done":
+			stats.Done++
+			continue
+		}
+
+		if todo.DueDate != "" && todo.DueDate < today {
+			stats.Overdue = append(stats.Overdue, todo)
+		}
+
+		if todo.Captured == "" {
+			stats.UndatedOpen++
+		} else if days, err := dateutil.DaysSince(todo.Captured, now); err == nil {
+			ageTotal += days
+			aged++
+		}
+	}
+	if aged > 0 {
+		stats.AvgOpenAgeDays = float64(ageTotal) / float64(aged)
+	}
+
+	if weeks <= 0 {
+		weeks = DefaultStatsWeeks
+	}
+
+	// Monday of the current week
+	monday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	monday = monday.AddDate(0, 0, -((int(monday.Weekday()) + 6) % 7))
+
+	for i := weeks - 1; i >= 0; i-- {
+		start := monday.AddDate(0, 0, -7*i).Format("2006-01-02")
+		end := monday.AddDate(0, 0, -7*i+7).Format("2006-01-02")
+		week := WeekStats{Start: start}
+
+		for _, todo := range todos {
+			if todo.Status == "done" && todo.DoneDate == "" {
+				continue
+			}
+			if todo.Captured >= start && todo.Captured < end {
+				week.Added++
+			}
+			if todo.DoneDate >= start && todo.DoneDate < end {
+				week.Completed++
+			}
+			if todo.Captured < end {
+				week.Scope++
+				if todo.Status != "done" || todo.DoneDate >= end {
+					week.Remaining++
+				}
+			}
+		}
+
+		stats.Weeks = append(stats.Weeks, week)
+	}
+
+	return stats
+}
+
+// BurnChart renders the weekly history as an ASCII burnup/burndown chart
+// Each row shows completed tasks as █ and remaining tasks as ░, so the filled
+// part grows as work gets done (burnup) and the shaded part shrinks (burndown).
+func BurnChart(weeks []WeekStats, width int) string {
+	maxScope := 0
+	for _, week := range weeks {
+		if week.Scope > maxScope {
+			maxScope = week.Scope
+		}
+	}
+
+	var sb strings.Builder
+	for _, week := range weeks {
+		done := week.Scope - week.Remaining
+		doneWidth, remainingWidth := done, week.Remaining
+		if maxScope > width {
+			doneWidth = scaleBar(done, maxScope, width)
+			remainingWidth = scaleBar(week.Scope, maxScope, width) - doneWidth
+		}
+
+		fmt.Fprintf(&sb, "%s │%s%s %d/%d done\n", week.Start,
+			strings.Repeat("█", doneWidth), strings.Repeat("░", remainingWidth), done, week.Scope)
+	}
+
+	return sb.String()
+}
+
+// scaleBar scales value from [0, max] to [0, width], rounding to the nearest cell
+func scaleBar(value, max, width int) int {
+	return (value*width + max/2) / max
+}
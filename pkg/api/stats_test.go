@@ -0,0 +1,83 @@
+package api
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestComputeStats(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	projectDir := tb.AddProject("web")
+	tb.WriteFile(filepath.Join(projectDir, "todo.md"), `# Tasks
+
+- [ ] Old task #captured:2024-01-01
+- [ ] Late #due:2024-01-10 #captured:2024-01-08
+- [>] Doing #captured:2024-01-09 #bug
+- [-] Undated
+- [x] Shipped #captured:2024-01-02 #done:2024-01-03
+- [x] Shipped later #captured:2024-01-08 #done:2024-01-11
+- [x] Done long ago
+`)
+
+	todos, err := ParseAllTodos(tb.ActiveDirPath, true)
+	if err != nil {
+		t.Fatalf("ParseAllTodos failed: %v", err)
+	}
+
+	// Friday of the week starting Monday 2024-01-08
+	now := time.Date(2024, 1, 12, 15, 0, 0, 0, time.Local)
+	stats := ComputeStats("web", todos, now, 2)
+
+	if stats.Open != 2 || stats.InProgress != 1 || stats.Blocked != 1 || stats.Done != 3 {
+		t.Errorf("Unexpected counts: %+v", stats)
+	}
+	if stats.Unfinished() != 4 {
+		t.Errorf("Expected 4 unfinished, got %d", stats.Unfinished())
+	}
+	if len(stats.Overdue) != 1 || stats.Overdue[0].Content != "Late" {
+		t.Errorf("Expected one overdue task, got %+v", stats.Overdue)
+	}
+	if stats.UndatedOpen != 1 {
+		t.Errorf("Expected 1 undated task, got %d", stats.UndatedOpen)
+	}
+	// Ages: 11, 4 and 3 days
+	if stats.AvgOpenAgeDays != 6 {
+		t.Errorf("Expected average age 6, got %v", stats.AvgOpenAgeDays)
+	}
+
+	want := []WeekStats{
+		{Start: "2024-01-01", Added: 2, Completed: 1, Scope: 3, Remaining: 2},
+		{Start: "2024-01-08", Added: 3, Completed: 1, Scope: 6, Remaining: 4},
+	}
+	if len(stats.Weeks) != len(want) {
+		t.Fatalf("Expected %d weeks, got %d", len(want), len(stats.Weeks))
+	}
+	for i := range want {
+		if stats.Weeks[i] != want[i] {
+			t.Errorf("Week %d: expected %+v, got %+v", i, want[i], stats.Weeks[i])
+		}
+	}
+}
+
+func TestBurnChart(t *testing.T) {
+	chart := BurnChart([]WeekStats{
+		{Start: "2024-01-01", Scope: 4, Remaining: 3},
+		{Start: "2024-01-08", Scope: 80, Remaining: 40},
+	}, 40)
+
+	lines := strings.Split(strings.TrimSpace(chart), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+	if !strings.Contains(lines[0], "1/4 done") {
+		t.Errorf("Unexpected first line: %s", lines[0])
+	}
+	// Scaled to the width: 20 done cells and 20 remaining cells
+	if strings.Count(lines[1], "█") != 20 || strings.Count(lines[1], "░") != 20 {
+		t.Errorf("Expected scaled bar, got %s", lines[1])
+	}
+}
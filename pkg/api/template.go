@@ -0,0 +1,125 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/markdown"
+)
+
+// TemplatesDirName is the brain directory holding note and project templates
+const TemplatesDirName = "templates"
+
+// templateVarPattern matches {{name}} with optional inner spaces
+var templateVarPattern = regexp.MustCompile(`\{\{\s*([a-zA-Z_]+)\s*\}\}`)
+
+// NoteTemplatesDir returns the directory with note templates: <brain>/templates/notes
+func NoteTemplatesDir(brainPath string) string {
+	return filepath.Join(brainPath, TemplatesDirName, "notes")
+}
+
+// ListNoteTemplates returns the names of available note templates (file names without .md)
+func ListNoteTemplates(brainPath string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(NoteTemplatesDir(brainPath), "*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		names = append(names, strings.TrimSuffix(filepath.Base(file), ".md"))
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// LoadNoteTemplate reads a note template by name
+func LoadNoteTemplate(brainPath, name string) (string, error) {
+	name = strings.TrimSuffix(name, ".md")
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid template name '%s'", name)
+	}
+
+	data, err := os.ReadFile(filepath.Join(NoteTemplatesDir(brainPath), name+".md"))
+	if os.IsNotExist(err) {
+		available, _ := ListNoteTemplates(brainPath)
+		if len(available) == 0 {
+			return "", fmt.Errorf("template '%s' not found (no templates in %s)", name, NoteTemplatesDir(brainPath))
+		}
+		return "", fmt.Errorf("template '%s' not found. Available: %s", name, strings.Join(available, ", "))
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read template: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// NoteTemplateVars returns the variables available in note templates:
+// {{date}}, {{time}}, {{weekday}}, {{project}} and {{title}}
+func NoteTemplateVars(title, project string, now time.Time) map[string]string {
+	return map[string]string{
+		"date":    now.Format("2006-01-02"),
+		"time":    now.Format("15:04"),
+		"weekday": now.Format("Monday"),
+		"project": project,
+		"title":   title,
+	}
+}
+
+// RenderTemplate replaces {{name}} variables with their values
+// Unknown variables are left untouched so typos stay visible
+func RenderTemplate(tmpl string, vars map[string]string) string {
+	return templateVarPattern.ReplaceAllStringFunc(tmpl, func(match string) string {
+		name := templateVarPattern.FindStringSubmatch(match)[1]
+		if value, ok := vars[strings.ToLower(name)]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// NoteTemplateContent renders a template into a new note
+// The template's own front matter is kept; title and dates are filled in when missing
+// and tags are merged in.
+func NoteTemplateContent(tmpl, title, created string, tags []string, vars map[string]string) string {
+	fm, body, _ := markdown.ParseFrontMatter(RenderTemplate(tmpl, vars))
+
+	if fm.Title == "" {
+		fm.Title = title
+	}
+	if fm.Created == "" {
+		fm.Created = created
+	}
+	if fm.Updated == "" {
+		fm.Updated = created
+	}
+	for _, tag := range tags {
+		if !containsFold(fm.Tags, tag) {
+			fm.Tags = append(fm.Tags, tag)
+		}
+	}
+
+	return fm.String() + "\n" + body
+}
+
+// TemplateBody renders a template and drops its front matter
+func TemplateBody(tmpl string, vars map[string]string) string {
+	_, body, _ := markdown.ParseFrontMatter(RenderTemplate(tmpl, vars))
+	return body
+}
+
+func containsFold(items []string, value string) bool {
+	for _, item := range items {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
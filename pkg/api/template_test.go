@@ -0,0 +1,98 @@
+package api
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sandermoonemans/local-brain/pkg/markdown"
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	vars := NoteTemplateVars("Sprint planning", "web", time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC))
+
+	got := RenderTemplate("# {{title}}\n{{ date }} {{time}} ({{weekday}}) in {{project}} {{unknown}}", vars)
+	want := "# Sprint planning\n2024-03-04 09:30 (Monday) in web {{unknown}}"
+	if got != want {
+		t.Errorf("RenderTemplate = %q, want %q", got, want)
+	}
+}
+
+func TestLoadNoteTemplate(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	if _, err := LoadNoteTemplate(tb.BrainPath, "meeting"); err == nil {
+		t.Error("Expected error when template is missing")
+	}
+
+	tb.WriteFile(filepath.Join(NoteTemplatesDir(tb.BrainPath), "meeting.md"), "# {{title}}\n")
+	tb.WriteFile(filepath.Join(NoteTemplatesDir(tb.BrainPath), "adr.md"), "# ADR\n")
+
+	names, err := ListNoteTemplates(tb.BrainPath)
+	if err != nil {
+		t.Fatalf("ListNoteTemplates failed: %v", err)
+	}
+	if strings.Join(names, ",") != "adr,meeting" {
+		t.Errorf("Expected [adr meeting], got %v", names)
+	}
+
+	tmpl, err := LoadNoteTemplate(tb.BrainPath, "meeting")
+	if err != nil {
+		t.Fatalf("LoadNoteTemplate failed: %v", err)
+	}
+	if tmpl != "# {{title}}\n" {
+		t.Errorf("Unexpected template content %q", tmpl)
+	}
+
+	_, err = LoadNoteTemplate(tb.BrainPath, "oneonone")
+	if err == nil || !strings.Contains(err.Error(), "adr, meeting") {
+		t.Errorf("Expected error listing available templates, got %v", err)
+	}
+}
+
+func TestNoteTemplateContent(t *testing.T) {
+	vars := NoteTemplateVars("Sprint planning", "web", time.Date(2024, 3, 4, 9, 30, 0, 0, time.UTC))
+
+	t.Run("template with front matter", func(t *testing.T) {
+		tmpl := "---\ntags: [meeting]\nattendees: []\n---\n# {{title}}\n\n## Notes\n"
+		content := NoteTemplateContent(tmpl, "Sprint planning", "2024-03-04", []string{"sprint"}, vars)
+
+		fm, body, found := markdown.ParseFrontMatter(content)
+		if !found {
+			t.Fatalf("Expected front matter, got:\n%s", content)
+		}
+		if fm.Title != "Sprint planning" || fm.Created != "2024-03-04" {
+			t.Errorf("Expected title and created to be filled in, got %+v", fm)
+		}
+		if strings.Join(fm.Tags, ",") != "meeting,sprint" {
+			t.Errorf("Expected merged tags, got %v", fm.Tags)
+		}
+		if len(fm.Extra) != 1 || fm.Extra[0] != "attendees: []" {
+			t.Errorf("Expected unknown keys preserved, got %v", fm.Extra)
+		}
+		if body != "# Sprint planning\n\n## Notes\n" {
+			t.Errorf("Unexpected body %q", body)
+		}
+	})
+
+	t.Run("template without front matter", func(t *testing.T) {
+		content := NoteTemplateContent("# {{title}}\n", "Sprint planning", "2024-03-04", nil, vars)
+
+		fm, body, found := markdown.ParseFrontMatter(content)
+		if !found || fm.Title != "Sprint planning" {
+			t.Fatalf("Expected generated front matter, got:\n%s", content)
+		}
+		if body != "# Sprint planning\n" {
+			t.Errorf("Unexpected body %q", body)
+		}
+	})
+}
+
+func TestTemplateBody(t *testing.T) {
+	body := TemplateBody("---\ntags: [meeting]\n---\n## Agenda for {{project}}\n", map[string]string{"project": "web"})
+	if body != "## Agenda for web\n" {
+		t.Errorf("Unexpected body %q", body)
+	}
+}
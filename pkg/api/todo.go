@@ -17,13 +17,18 @@ type TodoItem struct {
 	ID       string   `json:"id"`
 	File     string   `json:"file"`
 	Line     int      `json:"line"`
-	Status   string   `json:"status"`   // "open", "in-progress", "blocked", or "done"
+	Status   string   `json:"status"` // "open", "in-progress", "blocked", or "done"
 	Content  string   `json:"content"`
 	Project  string   `json:"project"`
-	Priority *int     `json:"priority"` // 1=high, 2=medium, 3=low, nil=unprioritized
-	DueDate  string   `json:"due_date"` // YYYY-MM-DD format, empty if no due date
-	Tags     []string `json:"tags"`     // Freeform tags (e.g., "bug", "feature", "urgent")
-	RawLine  string   `json:"-"`        // Original line for ID generation
+	Priority *int     `json:"priority"`            // 1=high, 2=medium, 3=low, nil=unprioritized
+	DueDate  string   `json:"due_date"`            // YYYY-MM-DD format, empty if no due date
+	Tags     []string `json:"tags"`                // Freeform tags (e.g., "bug", "feature", "urgent")
+	Ref      string   `json:"ref"`                 // Code reference "path[:line]", empty if none
+	DoneDate string   `json:"done_date,omitempty"` // YYYY-MM-DD the task was completed, if recorded
+	Captured string   `json:"captured,omitempty"`  // YYYY-MM-DD the task was captured, if recorded
+	Source   string   `json:"source"`              // File within the project: "todo.md", "notes.md" or "notes/<file>"
+	Archived bool     `json:"archived,omitempty"`  // Task belongs to an archived project
+	RawLine  string   `json:"-"`                   // Original line for ID generation
 }
 
 var (
@@ -31,39 +36,59 @@ var (
 	todoInProgressPattern = regexp.MustCompile(`^\s*- \[>\] (.+)$`)
 	todoBlockedPattern    = regexp.MustCompile(`^\s*- \[-\] (.+)$`)
 	todoDonePattern       = regexp.MustCompile(`^\s*- \[[xX]\] (.+)$`)
+	doneDatePattern       = regexp.MustCompile(`\s*#done:[0-9-]+`)
 )
 
 // ParseAllTodos scans all todo.md files in active projects
 func ParseAllTodos(activeDir string, includeCompleted bool) ([]TodoItem, error) {
+	return parseActiveTodos(activeDir, includeCompleted, false)
+}
+
+// ParseAllTodosWithNotes scans todo.md plus checkboxes in notes.md and notes/*.md
+// Tasks from notes have File and Line pointing into the note and Source set to it
+func ParseAllTodosWithNotes(activeDir string, includeCompleted bool) ([]TodoItem, error) {
+	return parseActiveTodos(activeDir, includeCompleted, true)
+}
+
+func parseActiveTodos(activeDir string, includeCompleted, includeNotes bool) ([]TodoItem, error) {
 	var todos []TodoItem
 
-	// Scan all project directories
-	entries, err := os.ReadDir(activeDir)
+	// Scan all project directories in every container
+	dirs, err := listProjectDirs(activeDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read active directory: %w", err)
+		return nil, err
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
-			continue
+	for _, dir := range dirs {
+		projectName := dir.Name
+		projectDir := dir.Path
+
+		files := []string{filepath.Join(projectDir, "todo.md")}
+		if includeNotes {
+			files = append(files, filepath.Join(projectDir, "notes.md"))
+			notes, _ := filepath.Glob(filepath.Join(projectDir, "notes", "*.md"))
+			files = append(files, notes...)
 		}
 
-		projectName := entry.Name()
-		todoFile := filepath.Join(activeDir, projectName, "todo.md")
+		for _, file := range files {
+			// Skip files that don't exist
+			if _, err := os.Stat(file); os.IsNotExist(err) {
+				continue
+			}
 
-		// Skip if todo.md doesn't exist
-		if _, err := os.Stat(todoFile); os.IsNotExist(err) {
-			continue
-		}
+			fileTodos, err := parseTodoFile(file, projectName, includeCompleted)
+			if err != nil {
+				// Log error but continue with other files
+				continue
+			}
 
-		// Parse todo.md
-		projectTodos, err := parseTodoFile(todoFile, projectName, includeCompleted)
-		if err != nil {
-			// Log error but continue with other projects
-			continue
-		}
+			source, _ := filepath.Rel(projectDir, file)
+			for i := range fileTodos {
+				fileTodos[i].Source = filepath.ToSlash(source)
+			}
 
-		todos = append(todos, projectTodos...)
+			todos = append(todos, fileTodos...)
+		}
 	}
 
 	return todos, nil
@@ -112,6 +137,9 @@ func parseTodoFile(filePath, projectName string, includeCompleted bool) ([]TodoI
 			rawContent := matches[1]
 			content, priority := markdown.ExtractPriority(rawContent)
 			content, dueDate := markdown.ExtractDueDate(content)
+			content, ref := markdown.ExtractRef(content)
+			content, doneDate := markdown.ExtractDoneDate(content)
+			content, captured := markdown.ExtractCapturedDate(content)
 			content, tags := markdown.ExtractTags(content)
 			id := GenerateTaskID(lineNum, line, mtime)
 
@@ -125,6 +153,9 @@ func parseTodoFile(filePath, projectName string, includeCompleted bool) ([]TodoI
 				Priority: priority,
 				DueDate:  dueDate,
 				Tags:     tags,
+				Ref:      ref,
+				DoneDate: doneDate,
+				Captured: captured,
 				RawLine:  line,
 			})
 		}
@@ -133,6 +164,11 @@ func parseTodoFile(filePath, projectName string, includeCompleted bool) ([]TodoI
 	return todos, scanner.Err()
 }
 
+// FromNote reports whether the task lives in a note rather than todo.md
+func (t TodoItem) FromNote() bool {
+	return t.Source != "" && t.Source != "todo.md"
+}
+
 // FindTodoByID finds a todo by its ID
 func FindTodoByID(todos []TodoItem, id string) *TodoItem {
 	for i := range todos {
@@ -183,7 +219,7 @@ func ToggleTodoStatus(todo *TodoItem, newStatus string) error {
 		line = regexp.MustCompile(`- \[[xX]\]`).ReplaceAllString(line, "- [ ]")
 	}
 
-	lines[todo.Line-1] = line
+	lines[todo.Line-1] = withDoneDate(line, newStatus, time.Now().Format("2006-01-02"))
 
 	// Write back
 	newContent := strings.Join(lines, "\n")
@@ -320,13 +356,25 @@ func SetTodoStatus(todo *TodoItem, newStatus string) error {
 
 	// Replace with new checkbox
 	newLine := checkboxPattern.ReplaceAllString(line, "${1}- ["+checkboxSymbol+"]")
-	lines[todo.Line-1] = newLine
+	lines[todo.Line-1] = withDoneDate(newLine, newStatus, time.Now().Format("2006-01-02"))
 
 	// Write back
 	newContent := strings.Join(lines, "\n")
 	return os.WriteFile(todo.File, []byte(newContent), 0644)
 }
 
+// withDoneDate records #done:<date> on a completed task line and drops it otherwise
+// An existing completion date is kept
+func withDoneDate(line, status, date string) string {
+	if status != "done" {
+		return doneDatePattern.ReplaceAllString(line, "")
+	}
+	if doneDatePattern.MatchString(line) {
+		return line
+	}
+	return strings.TrimRight(line, " ") + " #done:" + date
+}
+
 // SetTodoDueDate sets or clears the due date tag for a todo item
 // dueDate should be in YYYY-MM-DD format, or empty string to clear
 func SetTodoDueDate(todo *TodoItem, dueDate string) error {
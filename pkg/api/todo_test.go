@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sandermoonemans/local-brain/pkg/testutil"
 )
@@ -804,6 +805,41 @@ func TestSetTodoStatus(t *testing.T) {
 	}
 }
 
+func TestSetTodoStatus_DoneDate(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	tb.AddProject("done-date")
+	todoFile := filepath.Join(tb.ActiveDirPath, "done-date", "todo.md")
+	tb.WriteFile(todoFile, "# Test\n\n- [ ] Task 1 #bug\n- [x] Task 2 #done:2024-01-10\n")
+
+	todos, err := ParseAllTodos(tb.ActiveDirPath, true)
+	if err != nil {
+		t.Fatalf("ParseAllTodos failed: %v", err)
+	}
+	if todos[1].DoneDate != "2024-01-10" || todos[1].Content != "Task 2" {
+		t.Errorf("Expected done date parsed from content, got %+v", todos[1])
+	}
+
+	// Completing records today's date
+	if err := SetTodoStatus(&todos[0], "done"); err != nil {
+		t.Fatalf("SetTodoStatus failed: %v", err)
+	}
+	today := time.Now().Format("2006-01-02")
+	updatedContent, _ := os.ReadFile(todoFile)
+	if !strings.Contains(string(updatedContent), "- [x] Task 1 #bug #done:"+today) {
+		t.Errorf("Expected completion date to be recorded. File content:\n%s", updatedContent)
+	}
+
+	// Reopening drops it
+	if err := SetTodoStatus(&todos[1], "open"); err != nil {
+		t.Fatalf("SetTodoStatus failed: %v", err)
+	}
+	updatedContent, _ = os.ReadFile(todoFile)
+	if !strings.Contains(string(updatedContent), "- [ ] Task 2\n") {
+		t.Errorf("Expected completion date to be removed. File content:\n%s", updatedContent)
+	}
+}
+
 func TestSetTodoStatus_InvalidStatus(t *testing.T) {
 	tb := testutil.SetupTestBrain(t)
 
@@ -1308,3 +1344,53 @@ func equalStringSlices(a, b []string) bool {
 	}
 	return true
 }
+
+func TestParseAllTodosWithNotes(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+
+	projectDir := tb.AddProject("notes-tasks")
+	tb.WriteFile(filepath.Join(projectDir, "todo.md"), "# Tasks\n\n- [ ] Main task\n")
+	tb.WriteFile(filepath.Join(projectDir, "notes.md"), "# Notes\n\n- [ ] Loose action\n")
+	tb.WriteFile(filepath.Join(projectDir, "notes", "sync.md"), "# Sync\n\nDiscussed scope.\n\n- [ ] Send slides #p:1\n- [x] Book room\n")
+
+	todos, err := ParseAllTodos(tb.ActiveDirPath, true)
+	if err != nil {
+		t.Fatalf("ParseAllTodos failed: %v", err)
+	}
+	if len(todos) != 1 || todos[0].Source != "todo.md" || todos[0].FromNote() {
+		t.Fatalf("Expected only the todo.md task, got %+v", todos)
+	}
+	if (TodoItem{}).FromNote() {
+		t.Error("A task without a source should not count as from a note")
+	}
+
+	todos, err = ParseAllTodosWithNotes(tb.ActiveDirPath, false)
+	if err != nil {
+		t.Fatalf("ParseAllTodosWithNotes failed: %v", err)
+	}
+	if len(todos) != 3 {
+		t.Fatalf("Expected 3 open tasks, got %d: %+v", len(todos), todos)
+	}
+
+	var slides *TodoItem
+	for i := range todos {
+		if todos[i].Content == "Send slides" {
+			slides = &todos[i]
+		}
+	}
+	if slides == nil {
+		t.Fatal("Expected task from notes/sync.md")
+	}
+	if slides.Source != "notes/sync.md" || !slides.FromNote() || slides.Line != 5 || *slides.Priority != 1 {
+		t.Errorf("Unexpected note task %+v", slides)
+	}
+
+	// Mutations edit the note in place
+	if err := SetTodoStatus(slides, "done"); err != nil {
+		t.Fatalf("SetTodoStatus failed: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(projectDir, "notes", "sync.md"))
+	if !strings.Contains(string(data), "- [x] Send slides #p:1 #done:") {
+		t.Errorf("Expected note to be updated, got:\n%s", data)
+	}
+}
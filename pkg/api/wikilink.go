@@ -0,0 +1,285 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sandermoonemans/local-brain/pkg/fileutil"
+)
+
+// wikiLinkPattern matches [[target]], [[target#heading]] and [[target|label]]
+var wikiLinkPattern = regexp.MustCompile(`\[\[([^\[\]|#]+)(#[^\[\]|]*)?(\|[^\[\]]*)?\]\]`)
+
+// WikiLink is a [[note]] or [[project/note]] link found in a note or task file
+type WikiLink struct {
+	Target     string `json:"target"`      // Text between the brackets without heading or label
+	SourcePath string `json:"source_path"` // File containing the link
+	Project    string `json:"project"`     // Project of the source file
+	Line       int    `json:"line"`
+	Resolved   string `json:"resolved,omitempty"` // Path of the linked note, empty if broken
+}
+
+// LinkGraph indexes notes and wiki links across all active projects
+type LinkGraph struct {
+	Notes []NoteFile
+	Links []WikiLink
+}
+
+// ParseWikiLinks returns the wiki links in content with their line numbers
+func ParseWikiLinks(content string) []WikiLink {
+	var links []WikiLink
+	for i, line := range strings.Split(content, "\n") {
+		for _, match := range wikiLinkPattern.FindAllStringSubmatch(line, -1) {
+			target := strings.TrimSpace(match[1])
+			if target != "" {
+				links = append(links, WikiLink{Target: target, Line: i + 1})
+			}
+		}
+	}
+	return links
+}
+
+// BuildLinkGraph scans notes, notes.md and todo.md of every project in every container
+func BuildLinkGraph(activeDir string) (*LinkGraph, error) {
+	dirs, err := listProjectDirs(activeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &LinkGraph{}
+	sources := make(map[string]string) // Source path -> project
+	var order []string
+
+	for _, dir := range dirs {
+		projectDir := dir.Path
+
+		notes, err := ListNotes(projectDir)
+		if err != nil {
+			return nil, err
+		}
+		graph.Notes = append(graph.Notes, notes...)
+
+		for _, note := range notes {
+			sources[note.Path] = dir.Name
+			order = append(order, note.Path)
+		}
+		for _, name := range []string{"notes.md", "todo.md"} {
+			if path := filepath.Join(projectDir, name); fileutil.FileExists(path) {
+				sources[path] = dir.Name
+				order = append(order, path)
+			}
+		}
+	}
+
+	for _, source := range order {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			continue
+		}
+		project := sources[source]
+		for _, link := range ParseWikiLinks(string(data)) {
+			link.SourcePath = source
+			link.Project = project
+			if note, _ := graph.Resolve(link.Target, project); note != nil {
+				link.Resolved = note.Path
+			}
+			graph.Links = append(graph.Links, link)
+		}
+	}
+
+	return graph, nil
+}
+
+// Resolve finds the note a link target refers to, as seen from a project
+// Targets match a note's file name (without .md), title or alias, case-insensitively.
+// "project/note" looks only in that project; a bare name prefers the source
+// project and otherwise must be unique across the brain.
+// byName reports whether the match was on the file name (as opposed to title or alias).
+func (g *LinkGraph) Resolve(target, fromProject string) (note *NoteFile, byName bool) {
+	project, name, hasProject := strings.Cut(target, "/")
+	if !hasProject {
+		name = target
+		project = ""
+	}
+	name = strings.TrimSuffix(strings.TrimSpace(name), ".md")
+
+	// File name matches win over titles and aliases
+	for _, matchName := range []bool{true, false} {
+		var matches []*NoteFile
+		for i := range g.Notes {
+			candidate := &g.Notes[i]
+			if hasProject && !strings.EqualFold(candidate.Project, project) {
+				continue
+			}
+			if noteMatches(candidate, name, matchName) {
+				matches = append(matches, candidate)
+			}
+		}
+
+		if !hasProject && len(matches) > 1 {
+			var local []*NoteFile
+			for _, m := range matches {
+				if m.Project == fromProject {
+					local = append(local, m)
+				}
+			}
+			matches = local
+		}
+
+		if len(matches) == 1 {
+			return matches[0], matchName
+		}
+	}
+
+	return nil, false
+}
+
+// FindNote resolves a user-supplied note reference like a link target
+func (g *LinkGraph) FindNote(query, fromProject string) (*NoteFile, error) {
+	note, _ := g.Resolve(query, fromProject)
+	if note == nil {
+		return nil, fmt.Errorf("note '%s' not found", query)
+	}
+	return note, nil
+}
+
+// LinksFrom returns the links in a file
+func (g *LinkGraph) LinksFrom(path string) []WikiLink {
+	var links []WikiLink
+	for _, link := range g.Links {
+		if link.SourcePath == path {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// Backlinks returns the links pointing to a note
+func (g *LinkGraph) Backlinks(notePath string) []WikiLink {
+	var links []WikiLink
+	for _, link := range g.Links {
+		if link.Resolved == notePath {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// BrokenLinks returns links that do not resolve to any note
+func (g *LinkGraph) BrokenLinks() []WikiLink {
+	var links []WikiLink
+	for _, link := range g.Links {
+		if link.Resolved == "" {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// RenameNote renames a note file and rewrites every link to it by file name
+// Links that match the note by title or alias keep working and are left alone.
+// Returns the files whose links were rewritten.
+func RenameNote(activeDir string, note *NoteFile, newName string) ([]string, error) {
+	newName = strings.TrimSuffix(strings.TrimSpace(newName), ".md")
+	if newName == "" || strings.ContainsAny(newName, `/\[]|#`) {
+		return nil, fmt.Errorf("invalid note name '%s'", newName)
+	}
+
+	newPath := filepath.Join(filepath.Dir(note.Path), newName+".md")
+	if fileutil.FileExists(newPath) {
+		return nil, fmt.Errorf("note '%s' already exists", filepath.Base(newPath))
+	}
+
+	graph, err := BuildLinkGraph(activeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect sources with links to rewrite before touching any file
+	rewrite := make(map[string]string) // Source path -> project
+	for _, link := range graph.Backlinks(note.Path) {
+		if _, byName := graph.Resolve(link.Target, link.Project); byName {
+			rewrite[link.SourcePath] = link.Project
+		}
+	}
+
+	if err := os.Rename(note.Path, newPath); err != nil {
+		return nil, fmt.Errorf("failed to rename note: %w", err)
+	}
+
+	var changed []string
+	for source, project := range rewrite {
+		if source == note.Path {
+			source = newPath
+		}
+
+		updated, err := rewriteLinks(source, func(target string) (string, bool) {
+			resolved, byName := graph.Resolve(target, project)
+			if resolved == nil || resolved.Path != note.Path || !byName {
+				return "", false
+			}
+			if prefix, _, ok := strings.Cut(target, "/"); ok {
+				return prefix + "/" + newName, true
+			}
+			return newName, true
+		})
+		if err != nil {
+			return changed, fmt.Errorf("failed to update links in %s: %w", source, err)
+		}
+		if updated {
+			changed = append(changed, source)
+		}
+	}
+
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// rewriteLinks replaces link targets in a file, keeping headings and labels
+func rewriteLinks(path string, replace func(target string) (string, bool)) (bool, error) {
+	changed := false
+
+	err := fileutil.WithLock(path, func() error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		content := wikiLinkPattern.ReplaceAllStringFunc(string(data), func(match string) string {
+			parts := wikiLinkPattern.FindStringSubmatch(match)
+			newTarget, ok := replace(strings.TrimSpace(parts[1]))
+			if !ok {
+				return match
+			}
+			changed = true
+			return "[[" + newTarget + parts[2] + parts[3] + "]]"
+		})
+
+		if !changed {
+			return nil
+		}
+		return fileutil.AtomicWriteFile(path, []byte(content))
+	})
+
+	return changed, err
+}
+
+// noteMatches compares a link name with a note's file name, or its title and aliases
+func noteMatches(note *NoteFile, name string, byFileName bool) bool {
+	if byFileName {
+		return strings.EqualFold(strings.TrimSuffix(note.Filename, ".md"), name)
+	}
+	if strings.EqualFold(note.Title, name) {
+		return true
+	}
+	for _, alias := range note.Aliases {
+		if strings.EqualFold(alias, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sandermoonemans/local-brain/pkg/testutil"
+)
+
+func TestParseWikiLinks(t *testing.T) {
+	content := "See [[design-review]] and [[api/spec#Auth|the spec]].\n\nNo link [here].\n[[ roadmap ]]"
+
+	links := ParseWikiLinks(content)
+	if len(links) != 3 {
+		t.Fatalf("Expected 3 links, got %d: %+v", len(links), links)
+	}
+
+	expected := []struct {
+		target string
+		line   int
+	}{
+		{"design-review", 1},
+		{"api/spec", 1},
+		{"roadmap", 4},
+	}
+	for i, exp := range expected {
+		if links[i].Target != exp.target || links[i].Line != exp.line {
+			t.Errorf("Link %d: expected %s:%d, got %s:%d", i, exp.target, exp.line, links[i].Target, links[i].Line)
+		}
+	}
+}
+
+func setupLinkedBrain(t *testing.T) *testutil.TestBrain {
+	tb := testutil.SetupTestBrain(t)
+	web := tb.AddProject("web")
+	apiDir := tb.AddProject("api")
+
+	tb.WriteFile(filepath.Join(web, "notes", "design-review.md"), "---\ntitle: Design review\naliases: [dr]\n---\n# Design review\n\nSee [[api/spec]] and [[missing-note]].\n")
+	tb.WriteFile(filepath.Join(web, "notes", "roadmap.md"), "# Roadmap\n\nBased on [[design-review|the review]] and [[Design review]].\n")
+	tb.WriteFile(filepath.Join(apiDir, "notes", "spec.md"), "# Spec\n\nUsed by [[web/design-review#Decisions]] and [[dr]].\n")
+	tb.WriteFile(filepath.Join(web, "todo.md"), "# web\n\n## Active\n\n- [ ] Follow up on [[design-review]] #todo\n\n## Completed\n\n")
+
+	return tb
+}
+
+func TestBuildLinkGraph(t *testing.T) {
+	tb := setupLinkedBrain(t)
+
+	graph, err := BuildLinkGraph(tb.ActiveDirPath)
+	if err != nil {
+		t.Fatalf("BuildLinkGraph failed: %v", err)
+	}
+
+	reviewPath := filepath.Join(tb.ActiveDirPath, "web", "notes", "design-review.md")
+
+	backlinks := graph.Backlinks(reviewPath)
+	if len(backlinks) != 5 {
+		t.Fatalf("Expected 5 backlinks, got %d: %+v", len(backlinks), backlinks)
+	}
+
+	broken := graph.BrokenLinks()
+	if len(broken) != 1 || broken[0].Target != "missing-note" {
+		t.Errorf("Expected only missing-note to be broken, got %+v", broken)
+	}
+
+	outgoing := graph.LinksFrom(reviewPath)
+	if len(outgoing) != 2 {
+		t.Fatalf("Expected 2 outgoing links, got %d", len(outgoing))
+	}
+	if !strings.HasSuffix(outgoing[0].Resolved, filepath.Join("api", "notes", "spec.md")) {
+		t.Errorf("Expected [[api/spec]] to resolve to spec.md, got %q", outgoing[0].Resolved)
+	}
+}
+
+func TestLinkGraphResolve_PrefersSourceProject(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	web := tb.AddProject("web")
+	apiDir := tb.AddProject("api")
+	tb.WriteFile(filepath.Join(web, "notes", "ideas.md"), "# Web ideas\n")
+	tb.WriteFile(filepath.Join(apiDir, "notes", "ideas.md"), "# API ideas\n")
+
+	graph, err := BuildLinkGraph(tb.ActiveDirPath)
+	if err != nil {
+		t.Fatalf("BuildLinkGraph failed: %v", err)
+	}
+
+	note, byName := graph.Resolve("ideas", "api")
+	if note == nil || note.Project != "api" || !byName {
+		t.Errorf("Expected api/ideas by name, got %+v", note)
+	}
+
+	if note, _ := graph.Resolve("ideas", "other"); note != nil {
+		t.Errorf("Expected ambiguous link to stay unresolved, got %s", note.Path)
+	}
+}
+
+func TestRenameNote(t *testing.T) {
+	tb := setupLinkedBrain(t)
+
+	graph, err := BuildLinkGraph(tb.ActiveDirPath)
+	if err != nil {
+		t.Fatalf("BuildLinkGraph failed: %v", err)
+	}
+	note, err := graph.FindNote("web/design-review", "")
+	if err != nil {
+		t.Fatalf("FindNote failed: %v", err)
+	}
+
+	changed, err := RenameNote(tb.ActiveDirPath, note, "architecture-review")
+	if err != nil {
+		t.Fatalf("RenameNote failed: %v", err)
+	}
+	if len(changed) != 3 {
+		t.Errorf("Expected 3 files updated, got %v", changed)
+	}
+
+	newPath := filepath.Join(tb.ActiveDirPath, "web", "notes", "architecture-review.md")
+	if !tb.FileExists(newPath) || tb.FileExists(note.Path) {
+		t.Fatal("Expected note file to be renamed")
+	}
+
+	read := func(path string) string {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", path, err)
+		}
+		return string(data)
+	}
+
+	roadmap := read(filepath.Join(tb.ActiveDirPath, "web", "notes", "roadmap.md"))
+	if !strings.Contains(roadmap, "[[architecture-review|the review]]") {
+		t.Errorf("Expected label to be kept, got:\n%s", roadmap)
+	}
+	if !strings.Contains(roadmap, "[[Design review]]") {
+		t.Errorf("Expected title link to be left alone, got:\n%s", roadmap)
+	}
+
+	spec := read(filepath.Join(tb.ActiveDirPath, "api", "notes", "spec.md"))
+	if !strings.Contains(spec, "[[web/architecture-review#Decisions]]") || !strings.Contains(spec, "[[dr]]") {
+		t.Errorf("Expected project link rewritten and alias kept, got:\n%s", spec)
+	}
+
+	todo := read(filepath.Join(tb.ActiveDirPath, "web", "todo.md"))
+	if !strings.Contains(todo, "[[architecture-review]]") {
+		t.Errorf("Expected task link rewritten, got:\n%s", todo)
+	}
+}
+
+func TestRenameNote_Conflict(t *testing.T) {
+	tb := setupLinkedBrain(t)
+
+	graph, err := BuildLinkGraph(tb.ActiveDirPath)
+	if err != nil {
+		t.Fatalf("BuildLinkGraph failed: %v", err)
+	}
+	note, err := graph.FindNote("design-review", "web")
+	if err != nil {
+		t.Fatalf("FindNote failed: %v", err)
+	}
+
+	if _, err := RenameNote(tb.ActiveDirPath, note, "roadmap"); err == nil {
+		t.Error("Expected error when renaming onto an existing note")
+	}
+	if _, err := RenameNote(tb.ActiveDirPath, note, "bad/name"); err == nil {
+		t.Error("Expected error for invalid name")
+	}
+}
@@ -16,6 +16,22 @@ type BrainInfo struct {
 	Path    string `json:"path"`
 	Created string `json:"created"`
 	Focus   string `json:"focus,omitempty"`
+
+	// DumpMaxAge is the number of days before dump items count as stale
+	// nil uses the default; 0 disables aging warnings
+	DumpMaxAge *int `json:"dump_max_age,omitempty"`
+
+	// TodoIncludeNotes lists checkboxes from notes alongside todo.md tasks
+	TodoIncludeNotes bool `json:"todo_include_notes,omitempty"`
+
+	// DefaultProjectTemplate is used by project new when no --template is given
+	DefaultProjectTemplate string `json:"default_project_template,omitempty"`
+
+	// DevRoot is where linked repositories are checked out (default ~/dev)
+	DevRoot string `json:"dev_root,omitempty"`
+
+	// RepoLayout is the directory layout under DevRoot: "flat" or "host"
+	RepoLayout string `json:"repo_layout,omitempty"`
 }
 
 // Config represents the brain configuration
@@ -256,6 +272,150 @@ func (c *Config) GetFocusedProject() string {
 	return brain.Focus
 }
 
+// GetDumpMaxAge returns the stale threshold in days for the current brain
+func (c *Config) GetDumpMaxAge(defaultDays int) int {
+	currentBrain := c.GetCurrentBrain()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	brain, exists := c.Brains[currentBrain]
+	if !exists || brain.DumpMaxAge == nil {
+		return defaultDays
+	}
+
+	return *brain.DumpMaxAge
+}
+
+// SetDumpMaxAge sets the stale threshold in days for the current brain
+func (c *Config) SetDumpMaxAge(days int) error {
+	currentBrain := c.GetCurrentBrain()
+	if currentBrain == "" {
+		return fmt.Errorf("no current brain set")
+	}
+
+	if days < 0 {
+		return fmt.Errorf("threshold must not be negative")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	brain, exists := c.Brains[currentBrain]
+	if !exists {
+		return fmt.Errorf("current brain '%s' not found", currentBrain)
+	}
+
+	brain.DumpMaxAge = &days
+	return nil
+}
+
+// GetTodoIncludeNotes reports whether task lists include checkboxes from notes
+func (c *Config) GetTodoIncludeNotes() bool {
+	currentBrain := c.GetCurrentBrain()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	brain, exists := c.Brains[currentBrain]
+	if !exists {
+		return false
+	}
+
+	return brain.TodoIncludeNotes
+}
+
+// SetTodoIncludeNotes sets whether task lists include checkboxes from notes
+func (c *Config) SetTodoIncludeNotes(include bool) error {
+	currentBrain := c.GetCurrentBrain()
+	if currentBrain == "" {
+		return fmt.Errorf("no current brain set")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	brain, exists := c.Brains[currentBrain]
+	if !exists {
+		return fmt.Errorf("current brain '%s' not found", currentBrain)
+	}
+
+	brain.TodoIncludeNotes = include
+	return nil
+}
+
+// GetDefaultProjectTemplate returns the default project template for the current brain
+func (c *Config) GetDefaultProjectTemplate() string {
+	currentBrain := c.GetCurrentBrain()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	brain, exists := c.Brains[currentBrain]
+	if !exists {
+		return ""
+	}
+
+	return brain.DefaultProjectTemplate
+}
+
+// SetDefaultProjectTemplate sets the default project template for the current brain
+// An empty name restores the built-in project files
+func (c *Config) SetDefaultProjectTemplate(name string) error {
+	currentBrain := c.GetCurrentBrain()
+	if currentBrain == "" {
+		return fmt.Errorf("no current brain set")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	brain, exists := c.Brains[currentBrain]
+	if !exists {
+		return fmt.Errorf("current brain '%s' not found", currentBrain)
+	}
+
+	brain.DefaultProjectTemplate = name
+	return nil
+}
+
+// GetRepoLocation returns the dev root and checkout layout for the current brain
+// Empty values mean the defaults
+func (c *Config) GetRepoLocation() (devRoot, layout string) {
+	currentBrain := c.GetCurrentBrain()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	brain, exists := c.Brains[currentBrain]
+	if !exists {
+		return "", ""
+	}
+
+	return brain.DevRoot, brain.RepoLayout
+}
+
+// SetRepoLocation sets the dev root and checkout layout for the current brain
+// Empty values restore the defaults
+func (c *Config) SetRepoLocation(devRoot, layout string) error {
+	currentBrain := c.GetCurrentBrain()
+	if currentBrain == "" {
+		return fmt.Errorf("no current brain set")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	brain, exists := c.Brains[currentBrain]
+	if !exists {
+		return fmt.Errorf("current brain '%s' not found", currentBrain)
+	}
+
+	brain.DevRoot = devRoot
+	brain.RepoLayout = layout
+	return nil
+}
+
 // RenameBrain renames a brain in the configuration
 func (c *Config) RenameBrain(oldName, newName, newPath string) error {
 	c.mu.Lock()
@@ -273,11 +433,9 @@ func (c *Config) RenameBrain(oldName, newName, newPath string) error {
 	}
 
 	// Create new brain entry with updated path
-	c.Brains[newName] = &BrainInfo{
-		Path:    newPath,
-		Created: oldBrain.Created,
-		Focus:   oldBrain.Focus,
-	}
+	renamed := *oldBrain
+	renamed.Path = newPath
+	c.Brains[newName] = &renamed
 
 	// Delete old entry
 	delete(c.Brains, oldName)
@@ -218,6 +218,111 @@ func TestGetFocusedProject(t *testing.T) {
 	}
 }
 
+func TestDumpMaxAge(t *testing.T) {
+	_ = testutil.SetupTestBrain(t)
+	cfg, _ := Load()
+
+	// Default when unset
+	if days := cfg.GetDumpMaxAge(7); days != 7 {
+		t.Errorf("Expected default 7, got %d", days)
+	}
+
+	// Zero is a valid setting (disabled), not the default
+	if err := cfg.SetDumpMaxAge(0); err != nil {
+		t.Fatalf("SetDumpMaxAge failed: %v", err)
+	}
+	if days := cfg.GetDumpMaxAge(7); days != 0 {
+		t.Errorf("Expected 0, got %d", days)
+	}
+
+	if err := cfg.SetDumpMaxAge(14); err != nil {
+		t.Fatalf("SetDumpMaxAge failed: %v", err)
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, _ := Load()
+	if days := reloaded.GetDumpMaxAge(7); days != 14 {
+		t.Errorf("Expected 14 after reload, got %d", days)
+	}
+
+	if err := cfg.SetDumpMaxAge(-1); err == nil {
+		t.Error("Expected error for negative threshold")
+	}
+}
+
+func TestTodoIncludeNotes(t *testing.T) {
+	_ = testutil.SetupTestBrain(t)
+	cfg, _ := Load()
+
+	if cfg.GetTodoIncludeNotes() {
+		t.Error("Expected notes to be excluded by default")
+	}
+
+	if err := cfg.SetTodoIncludeNotes(true); err != nil {
+		t.Fatalf("SetTodoIncludeNotes failed: %v", err)
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, _ := Load()
+	if !reloaded.GetTodoIncludeNotes() {
+		t.Error("Expected setting to persist after reload")
+	}
+}
+
+func TestDefaultProjectTemplate(t *testing.T) {
+	tb := testutil.SetupTestBrain(t)
+	cfg, _ := Load()
+
+	if cfg.GetDefaultProjectTemplate() != "" {
+		t.Error("Expected no default project template")
+	}
+
+	if err := cfg.SetDefaultProjectTemplate("release"); err != nil {
+		t.Fatalf("SetDefaultProjectTemplate failed: %v", err)
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, _ := Load()
+	if reloaded.GetDefaultProjectTemplate() != "release" {
+		t.Errorf("Expected 'release' after reload, got '%s'", reloaded.GetDefaultProjectTemplate())
+	}
+
+	// Settings survive a brain rename
+	if err := reloaded.RenameBrain("test", "renamed", filepath.Join(tb.TmpDir, "renamed")); err != nil {
+		t.Fatalf("RenameBrain failed: %v", err)
+	}
+	if reloaded.GetDefaultProjectTemplate() != "release" {
+		t.Error("Expected default project template to survive rename")
+	}
+}
+
+func TestRepoLocation(t *testing.T) {
+	testutil.SetupTestBrain(t)
+	cfg, _ := Load()
+
+	if root, layout := cfg.GetRepoLocation(); root != "" || layout != "" {
+		t.Errorf("Expected default repo location, got %q %q", root, layout)
+	}
+
+	if err := cfg.SetRepoLocation("~/code", "host"); err != nil {
+		t.Fatalf("SetRepoLocation failed: %v", err)
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	reloaded, _ := Load()
+	if root, layout := reloaded.GetRepoLocation(); root != "~/code" || layout != "host" {
+		t.Errorf("Expected ~/code and host after reload, got %q %q", root, layout)
+	}
+}
+
 func TestRenameBrain(t *testing.T) {
 	tb := testutil.SetupTestBrain(t)
 	cfg, _ := Load()
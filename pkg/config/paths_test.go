@@ -1,7 +1,6 @@
 package config
 
 import (
-	"os"
 	"path/filepath"
 	"testing"
 
@@ -62,67 +61,8 @@ func TestGetArchivePath(t *testing.T) {
 		t.Fatalf("GetArchivePath failed: %v", err)
 	}
 
-	expected := filepath.Join(tb.BrainPath, "02_archive")
+	expected := filepath.Join(tb.BrainPath, "99_archive")
 	if archivePath != expected {
 		t.Errorf("Expected %q, got %q", expected, archivePath)
 	}
 }
-
-func TestGetLinkedRepos_Config(t *testing.T) {
-	tb := testutil.SetupTestBrain(t)
-	cfg, _ := Load()
-
-	// Create a project with .repos file
-	tb.AddProject("test-project")
-	reposFile := filepath.Join(tb.ActiveDirPath, "test-project", ".repos")
-
-	reposContent := `https://github.com/user/repo1.git
-https://github.com/user/repo2.git
-`
-	tb.WriteFile(reposFile, reposContent)
-
-	repos, err := GetLinkedRepos(cfg, "test-project")
-	if err != nil {
-		t.Fatalf("GetLinkedRepos failed: %v", err)
-	}
-
-	// Repos are expanded to ~/dev/reponame
-	devDir := filepath.Join(os.Getenv("HOME"), "dev")
-	if len(repos) != 2 {
-		t.Errorf("Expected 2 repos, got %d", len(repos))
-	}
-
-	expected := []string{
-		filepath.Join(devDir, "repo1"),
-		filepath.Join(devDir, "repo2"),
-	}
-
-	for i, repo := range repos {
-		if repo != expected[i] {
-			t.Errorf("Repo %d: expected %q, got %q", i, expected[i], repo)
-		}
-	}
-}
-
-func TestExtractRepoName(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected string
-	}{
-		{"https://github.com/user/myrepo.git", "myrepo"},
-		{"https://github.com/user/myrepo", "myrepo"},
-		{"git@github.com:user/myrepo.git", "myrepo"},
-		{"https://gitlab.com/org/sub/project.git", "project"},
-		{"/local/path/to/repo", "repo"},
-		{"invalid", ""},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			result := extractRepoName(tt.input)
-			if result != tt.expected {
-				t.Errorf("Expected %q, got %q", tt.expected, result)
-			}
-		})
-	}
-}
@@ -2,6 +2,7 @@ package dateutil
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -145,3 +146,37 @@ func parseDayName(input string, now time.Time) (string, error) {
 	result := now.AddDate(0, 0, daysUntil)
 	return result.Format("2006-01-02"), nil
 }
+
+// ParseDays converts a duration like 14, 14d or 2w to a number of days
+func ParseDays(input string) (int, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+
+	pattern := regexp.MustCompile(`^(\d+)([dw]?)$`)
+	matches := pattern.FindStringSubmatch(input)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid duration '%s' (expected e.g. 14d or 2w)", input)
+	}
+
+	days, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount: %s", matches[1])
+	}
+
+	if matches[2] == "w" {
+		days *= 7
+	}
+
+	return days, nil
+}
+
+// DaysSince returns the number of whole days between an ISO date and now
+func DaysSince(date string, now time.Time) (int, error) {
+	t, err := time.ParseInLocation("2006-01-02", date, now.Location())
+	if err != nil {
+		return 0, fmt.Errorf("invalid date format: %s", date)
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	// Round to absorb daylight saving shifts
+	return int(math.Round(today.Sub(t).Hours() / 24)), nil
+}
@@ -335,3 +335,62 @@ func TestParseNaturalDate_Whitespace(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDays(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"14", 14},
+		{"14d", 14},
+		{"2w", 14},
+		{" 3D ", 3},
+		{"0d", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseDays(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDays failed: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "-3d", "2m", "abc"} {
+		if _, err := ParseDays(input); err == nil {
+			t.Errorf("Expected error for '%s'", input)
+		}
+	}
+}
+
+func TestDaysSince(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		date     string
+		expected int
+	}{
+		{"2024-03-10", 0},
+		{"2024-03-09", 1},
+		{"2024-02-24", 15},
+		{"2024-03-12", -2},
+	}
+
+	for _, tt := range tests {
+		result, err := DaysSince(tt.date, now)
+		if err != nil {
+			t.Fatalf("DaysSince failed: %v", err)
+		}
+		if result != tt.expected {
+			t.Errorf("DaysSince(%s): expected %d, got %d", tt.date, tt.expected, result)
+		}
+	}
+
+	if _, err := DaysSince("not-a-date", now); err == nil {
+		t.Error("Expected error for invalid date")
+	}
+}
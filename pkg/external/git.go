@@ -19,10 +19,14 @@ func VerifyRemote(url string) error {
 	return nil
 }
 
-// Clone clones a git repository
-// Equivalent to: git clone <url> <dest>
-func Clone(url, dest string) error {
-	cmd := exec.Command("git", "clone", url, dest)
+// Clone clones a git repository, checking out branch if not empty
+// Equivalent to: git clone [--branch <branch>] <url> <dest>
+func Clone(url, dest, branch string) error {
+	args := []string{"clone"}
+	if branch != "" {
+		args = append(args, "--branch", branch)
+	}
+	cmd := exec.Command("git", append(args, url, dest)...)
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -112,3 +116,24 @@ func ExtractRepoName(gitURL string) string {
 	// Return the last part
 	return parts[len(parts)-1]
 }
+
+// ListFiles returns the tracked and untracked files of a repository, respecting .gitignore
+// Paths are relative to the repository root
+// Equivalent to: git -C <repoPath> ls-files --cached --others --exclude-standard
+func ListFiles(repoPath string) ([]string, error) {
+	cmd := exec.Command("git", "-C", repoPath, "ls-files", "--cached", "--others", "--exclude-standard")
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files failed: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+
+	return files, nil
+}
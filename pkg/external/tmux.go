@@ -77,6 +77,19 @@ func KillSession(name string) error {
 	return nil
 }
 
+// RenameSession renames a tmux session
+// Equivalent to: tmux rename-session -t <old> <new>
+func RenameSession(oldName, newName string) error {
+	cmd := exec.Command("tmux", "rename-session", "-t", oldName, newName)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to rename tmux session: %w: %s", err, string(output))
+	}
+
+	return nil
+}
+
 // ListSessions returns a list of tmux session names
 // Equivalent to: tmux list-sessions -F "#{session_name}"
 func ListSessions() ([]string, error) {
@@ -0,0 +1,205 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FrontMatter holds the YAML-style metadata block at the top of a note
+//
+//	---
+//	title: Design review
+//	created: 2024-01-15
+//	updated: 2024-02-01
+//	tags: [architecture, api]
+//	aliases: [design]
+//	---
+//
+// Only simple "key: value" pairs and lists (flow [a, b] or block "- a") are
+// supported. Unknown keys are kept verbatim in Extra so they survive a rewrite.
+type FrontMatter struct {
+	Title   string
+	Created string
+	Updated string
+	Tags    []string
+	Aliases []string
+	Extra   []string // Unrecognized lines, preserved in order
+}
+
+const frontMatterDelimiter = "---"
+
+// ParseFrontMatter splits a front matter block from the rest of the content
+// Returns found=false and the unchanged content if there is no front matter
+func ParseFrontMatter(content string) (fm FrontMatter, body string, found bool) {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimRight(lines[0], " \r") != frontMatterDelimiter {
+		return FrontMatter{}, content, false
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], " \r") == frontMatterDelimiter {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return FrontMatter{}, content, false
+	}
+
+	var listKey string // Key of the block list being read, if any
+	for _, line := range lines[1:end] {
+		trimmed := strings.TrimSpace(line)
+
+		// Block list item belonging to the previous key
+		if listKey != "" && strings.HasPrefix(trimmed, "- ") {
+			fm.appendList(listKey, unquote(strings.TrimSpace(trimmed[2:])))
+			continue
+		}
+		listKey = ""
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.HasPrefix(line, " ") || trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			fm.Extra = append(fm.Extra, line)
+			continue
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "title":
+			fm.Title = unquote(value)
+		case "created":
+			fm.Created = unquote(value)
+		case "updated":
+			fm.Updated = unquote(value)
+		case "tags", "aliases":
+			if value == "" {
+				listKey = key
+				continue
+			}
+			for _, item := range parseFlowList(value) {
+				fm.appendList(key, item)
+			}
+		default:
+			fm.Extra = append(fm.Extra, line)
+		}
+	}
+
+	body = strings.Join(lines[end+1:], "\n")
+	return fm, strings.TrimPrefix(body, "\n"), true
+}
+
+// String renders the front matter block including delimiters and a trailing newline
+func (fm FrontMatter) String() string {
+	var sb strings.Builder
+	sb.WriteString(frontMatterDelimiter + "\n")
+	if fm.Title != "" {
+		fmt.Fprintf(&sb, "title: %s\n", quoteIfNeeded(fm.Title))
+	}
+	if fm.Created != "" {
+		fmt.Fprintf(&sb, "created: %s\n", fm.Created)
+	}
+	if fm.Updated != "" {
+		fmt.Fprintf(&sb, "updated: %s\n", fm.Updated)
+	}
+	if len(fm.Tags) > 0 {
+		fmt.Fprintf(&sb, "tags: %s\n", formatFlowList(fm.Tags))
+	}
+	if len(fm.Aliases) > 0 {
+		fmt.Fprintf(&sb, "aliases: %s\n", formatFlowList(fm.Aliases))
+	}
+	for _, line := range fm.Extra {
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString(frontMatterDelimiter + "\n")
+	return sb.String()
+}
+
+func (fm *FrontMatter) appendList(key, item string) {
+	if item == "" {
+		return
+	}
+	switch key {
+	case "tags":
+		fm.Tags = append(fm.Tags, strings.TrimPrefix(item, "#"))
+	case "aliases":
+		fm.Aliases = append(fm.Aliases, item)
+	}
+}
+
+// parseFlowList parses "[a, b]" or a bare "a, b" into items
+// Commas inside quoted items do not split them.
+func parseFlowList(value string) []string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+
+	var items []string
+	add := func(item string) {
+		if item = unquote(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	var quote byte // Quote character of the item being read, if any
+	start := 0
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++ // Skip the escaped character
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\'') && strings.TrimSpace(value[start:i]) == "":
+			quote = c
+		case quote == 0 && c == ',':
+			add(value[start:i])
+			start = i + 1
+		}
+	}
+	add(value[start:])
+	return items
+}
+
+func formatFlowList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = quoteIfNeeded(item)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// unquote strips YAML quotes and resolves their escapes (\" and \\ in double
+// quotes, a doubled quote in single quotes)
+func unquote(value string) string {
+	if len(value) < 2 {
+		return value
+	}
+	first, last := value[0], value[len(value)-1]
+	switch {
+	case first == '"' && last == '"':
+		inner := value[1 : len(value)-1]
+		var sb strings.Builder
+		for i := 0; i < len(inner); i++ {
+			if inner[i] == '\\' && i+1 < len(inner) {
+				i++
+			}
+			sb.WriteByte(inner[i])
+		}
+		return sb.String()
+	case first == '\'' && last == '\'':
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	return value
+}
+
+// quoteIfNeeded quotes values containing characters with meaning in YAML
+// Backslashes and double quotes inside the value are escaped.
+func quoteIfNeeded(value string) string {
+	if strings.ContainsAny(value, ":#[],\"'{}") || strings.HasPrefix(value, "- ") {
+		escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+		return `"` + escaped + `"`
+	}
+	return value
+}
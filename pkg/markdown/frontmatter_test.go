@@ -0,0 +1,107 @@
+package markdown
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	content := `---
+title: "Design: review"
+created: 2024-01-15
+updated: 2024-02-01
+tags: [architecture, "#api"]
+aliases:
+  - design
+  - 'review notes'
+status: draft
+---
+
+# Design: review
+
+Body text.
+`
+
+	fm, body, found := ParseFrontMatter(content)
+	if !found {
+		t.Fatal("Expected front matter to be found")
+	}
+
+	if fm.Title != "Design: review" || fm.Created != "2024-01-15" || fm.Updated != "2024-02-01" {
+		t.Errorf("Unexpected scalar fields: %+v", fm)
+	}
+	if !reflect.DeepEqual(fm.Tags, []string{"architecture", "api"}) {
+		t.Errorf("Unexpected tags: %v", fm.Tags)
+	}
+	if !reflect.DeepEqual(fm.Aliases, []string{"design", "review notes"}) {
+		t.Errorf("Unexpected aliases: %v", fm.Aliases)
+	}
+	if !reflect.DeepEqual(fm.Extra, []string{"status: draft"}) {
+		t.Errorf("Unexpected extra lines: %v", fm.Extra)
+	}
+	if body != "# Design: review\n\nBody text.\n" {
+		t.Errorf("Unexpected body: %q", body)
+	}
+}
+
+func TestParseFrontMatter_None(t *testing.T) {
+	tests := []string{
+		"# Legacy note\n\nCreated: 2024-01-15\n",
+		"---\ntitle: unterminated\n",
+		"",
+	}
+
+	for _, content := range tests {
+		_, body, found := ParseFrontMatter(content)
+		if found {
+			t.Errorf("Expected no front matter in %q", content)
+		}
+		if body != content {
+			t.Errorf("Expected body to be unchanged, got %q", body)
+		}
+	}
+}
+
+func TestFrontMatter_RoundTrip(t *testing.T) {
+	fm := FrontMatter{
+		Title:   "Meeting: Q1 planning",
+		Created: "2024-01-15",
+		Tags:    []string{"meeting", "planning"},
+		Extra:   []string{"status: draft"},
+	}
+
+	rendered := fm.String()
+	expected := "---\ntitle: \"Meeting: Q1 planning\"\ncreated: 2024-01-15\ntags: [meeting, planning]\nstatus: draft\n---\n"
+	if rendered != expected {
+		t.Errorf("Unexpected rendering:\n%s", rendered)
+	}
+
+	parsed, _, found := ParseFrontMatter(rendered + "\nbody")
+	if !found || !reflect.DeepEqual(parsed, fm) {
+		t.Errorf("Round trip mismatch: %+v", parsed)
+	}
+}
+
+func TestFrontMatter_RoundTripQuotes(t *testing.T) {
+	fm := FrontMatter{
+		Title:   `The "big" rewrite: it's C:\work`,
+		Aliases: []string{`say "hi", then leave`, "it's", `back\slash`},
+	}
+
+	rendered := fm.String()
+	if !strings.Contains(rendered, `title: "The \"big\" rewrite: it's C:\\work"`) {
+		t.Errorf("Expected escaped title, got:\n%s", rendered)
+	}
+
+	parsed, _, found := ParseFrontMatter(rendered + "\nbody")
+	if !found || !reflect.DeepEqual(parsed, fm) {
+		t.Errorf("Round trip mismatch:\n%s\n%+v", rendered, parsed)
+	}
+
+	// Single-quoted YAML escapes a quote by doubling it
+	parsed, _, _ = ParseFrontMatter("---\ntitle: 'it''s done'\ntags: ['a, b', c]\n---\n")
+	if parsed.Title != "it's done" || !reflect.DeepEqual(parsed.Tags, []string{"a, b", "c"}) {
+		t.Errorf("Unexpected single-quoted parse: %+v", parsed)
+	}
+}
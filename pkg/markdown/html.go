@@ -0,0 +1,261 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// HTMLRenderer converts the markdown subset used in brain files to HTML
+// Supported: headings, paragraphs, nested lists, task checkboxes ([ ] [x] [>] [-]),
+// fenced code, blockquotes, rules, inline code, emphasis, links, images and [[wiki links]].
+type HTMLRenderer struct {
+	// WikiLink resolves a [[target]] to an href; unresolved links render as plain text
+	WikiLink func(target string) (string, bool)
+}
+
+var (
+	htmlHeadingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	htmlRulePattern     = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	htmlListPattern     = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	htmlCheckboxPattern = regexp.MustCompile(`^\[([ xX>-])\]\s+(.*)$`)
+	htmlQuotePattern    = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	htmlFencePattern    = regexp.MustCompile("^\\s*(```|~~~)\\s*([A-Za-z0-9_+-]*)")
+
+	htmlCodeSpanPattern = regexp.MustCompile("`([^`]+)`")
+	htmlImagePattern    = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\)`)
+	htmlLinkPattern     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	htmlWikiPattern     = regexp.MustCompile(`\[\[([^\[\]|#]+)(#[^\[\]|]*)?(?:\|([^\[\]]*))?\]\]`)
+	htmlBoldPattern     = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	htmlItalicPattern   = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b`)
+)
+
+// RenderHTML renders markdown with the default renderer
+func RenderHTML(md string) string {
+	return (&HTMLRenderer{}).Render(md)
+}
+
+// RenderInline renders a single line of markdown without a surrounding block element
+func (r *HTMLRenderer) RenderInline(text string) string {
+	return r.inline(text)
+}
+
+// Render converts markdown to an HTML fragment
+func (r *HTMLRenderer) Render(md string) string {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+
+	var out strings.Builder
+	var paragraph []string
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			fmt.Fprintf(&out, "<p>%s</p>\n", r.inline(strings.Join(paragraph, " ")))
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			flushParagraph()
+
+		case htmlFencePattern.MatchString(line):
+			flushParagraph()
+			fence := htmlFencePattern.FindStringSubmatch(line)
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence[1]); i++ {
+				code = append(code, lines[i])
+			}
+			class := ""
+			if fence[2] != "" {
+				class = fmt.Sprintf(` class="language-%s"`, html.EscapeString(fence[2]))
+			}
+			fmt.Fprintf(&out, "<pre><code%s>%s</code></pre>\n", class, html.EscapeString(strings.Join(code, "\n")))
+
+		case htmlHeadingPattern.MatchString(line):
+			flushParagraph()
+			m := htmlHeadingPattern.FindStringSubmatch(line)
+			level := len(m[1])
+			fmt.Fprintf(&out, "<h%d>%s</h%d>\n", level, r.inline(m[2]), level)
+
+		case htmlRulePattern.MatchString(line):
+			flushParagraph()
+			out.WriteString("<hr>\n")
+
+		case htmlQuotePattern.MatchString(line):
+			flushParagraph()
+			var quote []string
+			for ; i < len(lines) && htmlQuotePattern.MatchString(lines[i]); i++ {
+				quote = append(quote, htmlQuotePattern.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			fmt.Fprintf(&out, "<blockquote>\n%s</blockquote>\n", r.Render(strings.Join(quote, "\n")))
+
+		case htmlListPattern.MatchString(line):
+			flushParagraph()
+			start := i
+			for i++; i < len(lines); i++ {
+				next := lines[i]
+				if htmlListPattern.MatchString(next) {
+					continue
+				}
+				// Indented continuation lines belong to the previous item
+				if strings.TrimSpace(next) != "" && (strings.HasPrefix(next, "  ") || strings.HasPrefix(next, "\t")) {
+					continue
+				}
+				break
+			}
+			r.renderList(&out, lines[start:i])
+			i--
+
+		default:
+			paragraph = append(paragraph, strings.TrimSpace(line))
+		}
+	}
+	flushParagraph()
+
+	return out.String()
+}
+
+// listItem is a parsed list line with its nesting depth
+type listItem struct {
+	indent  int
+	ordered bool
+	text    string
+}
+
+// renderList renders consecutive list lines, nesting by indentation
+func (r *HTMLRenderer) renderList(out *strings.Builder, lines []string) {
+	var items []listItem
+	for _, line := range lines {
+		m := htmlListPattern.FindStringSubmatch(line)
+		if m == nil {
+			// Continuation of the previous item
+			if len(items) > 0 {
+				items[len(items)-1].text += " " + strings.TrimSpace(line)
+			}
+			continue
+		}
+		indent := len(strings.ReplaceAll(m[1], "\t", "    "))
+		ordered := m[2] != "-" && m[2] != "*" && m[2] != "+"
+		items = append(items, listItem{indent: indent, ordered: ordered, text: m[3]})
+	}
+
+	type level struct {
+		indent int
+		tag    string
+	}
+	var stack []level
+
+	for _, item := range items {
+		tag := "ul"
+		if item.ordered {
+			tag = "ol"
+		}
+
+		// Close deeper lists
+		for len(stack) > 0 && item.indent < stack[len(stack)-1].indent {
+			fmt.Fprintf(out, "</li>\n</%s>\n", stack[len(stack)-1].tag)
+			stack = stack[:len(stack)-1]
+		}
+
+		// A different list type at the same depth starts a new list
+		if len(stack) > 0 && item.indent == stack[len(stack)-1].indent && tag != stack[len(stack)-1].tag {
+			fmt.Fprintf(out, "</li>\n</%s>\n", stack[len(stack)-1].tag)
+			stack = stack[:len(stack)-1]
+		}
+
+		switch {
+		case len(stack) == 0:
+			stack = append(stack, level{indent: item.indent, tag: tag})
+			fmt.Fprintf(out, "<%s>\n", tag)
+		case item.indent > stack[len(stack)-1].indent:
+			stack = append(stack, level{indent: item.indent, tag: tag})
+			fmt.Fprintf(out, "\n<%s>\n", tag)
+		default:
+			out.WriteString("</li>\n")
+		}
+
+		out.WriteString(r.listItemHTML(item.text))
+	}
+
+	for len(stack) > 0 {
+		fmt.Fprintf(out, "</li>\n</%s>\n", stack[len(stack)-1].tag)
+		stack = stack[:len(stack)-1]
+	}
+}
+
+// listItemHTML renders a list item, turning [ ] [x] [>] [-] into task markers
+func (r *HTMLRenderer) listItemHTML(text string) string {
+	m := htmlCheckboxPattern.FindStringSubmatch(text)
+	if m == nil {
+		return "<li>" + r.inline(text)
+	}
+
+	var status, marker string
+	switch m[1] {
+	case "x", "X":
+		status, marker = "done", `<input type="checkbox" disabled checked>`
+	case ">":
+		status, marker = "in-progress", `<span class="marker">&#9654;</span>`
+	case "-":
+		status, marker = "blocked", `<span class="marker">&#8856;</span>`
+	default:
+		status, marker = "open", `<input type="checkbox" disabled>`
+	}
+	return fmt.Sprintf(`<li class="task %s">%s %s`, status, marker, r.inline(m[2]))
+}
+
+// inline renders escaped text with code spans, links, images, wiki links and emphasis
+func (r *HTMLRenderer) inline(text string) string {
+	// Protect code spans from further processing
+	var spans []string
+	text = htmlCodeSpanPattern.ReplaceAllStringFunc(text, func(match string) string {
+		spans = append(spans, "<code>"+html.EscapeString(match[1:len(match)-1])+"</code>")
+		return fmt.Sprintf("\x00%d\x00", len(spans)-1)
+	})
+
+	text = html.EscapeString(text)
+
+	text = htmlWikiPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := htmlWikiPattern.FindStringSubmatch(match)
+		target := strings.TrimSpace(html.UnescapeString(m[1]))
+		label := m[1]
+		if m[3] != "" {
+			label = m[3]
+		}
+		if r.WikiLink != nil {
+			if href, ok := r.WikiLink(target); ok {
+				return fmt.Sprintf(`<a class="wikilink" href="%s">%s</a>`, html.EscapeString(href), label)
+			}
+		}
+		return fmt.Sprintf(`<span class="wikilink broken">%s</span>`, label)
+	})
+	text = htmlImagePattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := htmlImagePattern.FindStringSubmatch(match)
+		return fmt.Sprintf(`<img src="%s" alt="%s">`, safeURL(m[2]), m[1])
+	})
+	text = htmlLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := htmlLinkPattern.FindStringSubmatch(match)
+		return fmt.Sprintf(`<a href="%s">%s</a>`, safeURL(m[2]), m[1])
+	})
+	text = htmlBoldPattern.ReplaceAllString(text, `<strong>$1$2</strong>`)
+	text = htmlItalicPattern.ReplaceAllString(text, `<em>$1$2</em>`)
+
+	for i, span := range spans {
+		text = strings.Replace(text, fmt.Sprintf("\x00%d\x00", i), span, 1)
+	}
+	return text
+}
+
+// safeURL drops script URLs from links and images
+func safeURL(url string) string {
+	lower := strings.ToLower(strings.TrimSpace(html.UnescapeString(url)))
+	if strings.HasPrefix(lower, "javascript:") || strings.HasPrefix(lower, "vbscript:") || strings.HasPrefix(lower, "data:text") {
+		return "#"
+	}
+	return url
+}
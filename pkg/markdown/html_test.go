@@ -0,0 +1,98 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "heading and paragraph",
+			input:    "# Title\n\nSome **bold** and *italic* text.",
+			contains: []string{"<h1>Title</h1>", "<p>Some <strong>bold</strong> and <em>italic</em> text.</p>"},
+		},
+		{
+			name:     "escapes html",
+			input:    "a <script>alert(1)</script> & b",
+			contains: []string{"&lt;script&gt;", "&amp; b"},
+			excludes: []string{"<script>"},
+		},
+		{
+			name:     "code span is not formatted",
+			input:    "run `go **test** <x>`",
+			contains: []string{"<code>go **test** &lt;x&gt;</code>"},
+		},
+		{
+			name:     "fenced code",
+			input:    "```go\nfmt.Println(\"<hi>\")\n```",
+			contains: []string{`<pre><code class="language-go">fmt.Println(&#34;&lt;hi&gt;&#34;)`},
+		},
+		{
+			name:     "nested list",
+			input:    "- one\n  - two\n- three",
+			contains: []string{"<ul>\n<li>one\n<ul>\n<li>two</li>\n</ul>\n</li>\n<li>three</li>\n</ul>"},
+		},
+		{
+			name:     "task checkboxes",
+			input:    "- [ ] open\n- [x] done\n- [>] going\n- [-] stuck",
+			contains: []string{`class="task open"`, `class="task done"`, `class="task in-progress"`, `class="task blocked"`},
+		},
+		{
+			name:     "links and images",
+			input:    "[site](https://example.com) ![shot](attachments/a.png)",
+			contains: []string{`<a href="https://example.com">site</a>`, `<img src="attachments/a.png" alt="shot">`},
+		},
+		{
+			name:     "unsafe link",
+			input:    "[x](javascript:alert(1))",
+			contains: []string{`href="#"`},
+			excludes: []string{"javascript:"},
+		},
+		{
+			name:     "unresolved wiki link",
+			input:    "see [[Other Note|other]]",
+			contains: []string{`<span class="wikilink broken">other</span>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderHTML(tt.input)
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("Expected %q in output:\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("Did not expect %q in output:\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+}
+
+func TestHTMLRendererWikiLink(t *testing.T) {
+	r := &HTMLRenderer{
+		WikiLink: func(target string) (string, bool) {
+			if target == "design" {
+				return "notes/design.html", true
+			}
+			return "", false
+		},
+	}
+
+	got := r.RenderInline("[[design#api|the design]] and [[missing]]")
+	if !strings.Contains(got, `<a class="wikilink" href="notes/design.html">the design</a>`) {
+		t.Errorf("Expected resolved wiki link, got %s", got)
+	}
+	if !strings.Contains(got, `<span class="wikilink broken">missing</span>`) {
+		t.Errorf("Expected broken wiki link, got %s", got)
+	}
+}
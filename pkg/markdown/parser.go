@@ -11,27 +11,40 @@ import (
 type ItemType string
 
 const (
-	ItemTypeTodo ItemType = "todo"
-	ItemTypeNote ItemType = "note"
+	ItemTypeTodo     ItemType = "todo"
+	ItemTypeNote     ItemType = "note"
+	ItemTypeLink     ItemType = "link"
+	ItemTypeIdea     ItemType = "idea"
+	ItemTypeQuestion ItemType = "question"
 )
 
+// LineItemTypes maps the bracketed prefix of single-line dump items to their type
+// e.g. "[Link] https://example.com Title" or "[Idea] Build a CLI"
+// Register new single-line item types here
+var LineItemTypes = map[string]ItemType{
+	"Link":     ItemTypeLink,
+	"Idea":     ItemTypeIdea,
+	"Question": ItemTypeQuestion,
+}
+
 // DumpItem represents a parsed item from the dump file
 type DumpItem struct {
 	StartLine int
 	EndLine   int
 	Type      ItemType
-	Content   string // Full content including any metadata
-	RawLine   string // For tasks: the complete line; For notes: the title
+	Content   string // Full content including any metadata (without the checkbox or [Prefix])
+	RawLine   string // For single-line items: the complete line; For notes: the title
 }
 
 var (
-	taskPattern   = regexp.MustCompile(`^- \[ \] (.+)$`)
-	notePattern   = regexp.MustCompile(`^\[Note\] (.+)$`)
-	headerPattern = regexp.MustCompile(`^#+`)
-	indentPattern = regexp.MustCompile(`^    `) // 4 spaces
+	taskPattern     = regexp.MustCompile(`^- \[ \] (.+)$`)
+	notePattern     = regexp.MustCompile(`^\[Note\] (.+)$`)
+	lineItemPattern = regexp.MustCompile(`^\[([A-Za-z]+)\] (.+)$`)
+	headerPattern   = regexp.MustCompile(`^#+`)
+	indentPattern   = regexp.MustCompile(`^    `) // 4 spaces
 )
 
-// ParseDumpFile parses a dump file and returns all tasks and notes
+// ParseDumpFile parses a dump file and returns all tasks, notes and single-line items
 // This replicates the parse_dump_items function from brain-api.sh (lines 33-83)
 func ParseDumpFile(filePath string) ([]DumpItem, error) {
 	file, err := os.Open(filePath)
@@ -97,6 +110,17 @@ func ParseDumpFile(filePath string) ([]DumpItem, error) {
 			noteStart = lineNum
 			noteTitle = matches[1]
 			noteRawLine = noteTitle // For notes, we use the title for ID generation
+		} else if matches := lineItemPattern.FindStringSubmatch(line); matches != nil {
+			// Detect single-line items like [Link], [Idea], [Question]
+			if itemType, ok := LineItemTypes[matches[1]]; ok {
+				items = append(items, DumpItem{
+					StartLine: lineNum,
+					EndLine:   lineNum,
+					Type:      itemType,
+					Content:   matches[2],
+					RawLine:   line,
+				})
+			}
 		}
 	}
 
@@ -134,6 +158,18 @@ func ExtractTimestamp(content string) (string, string) {
 	return cleanContent, timestamp
 }
 
+// ParseLink splits link item content ("url optional title") into URL and title
+// If no title is given, the URL is returned as the title
+func ParseLink(content string) (string, string) {
+	content = strings.TrimSpace(content)
+	url, title, _ := strings.Cut(content, " ")
+	title = strings.TrimSpace(title)
+	if title == "" {
+		title = url
+	}
+	return url, title
+}
+
 // ExtractPriority extracts the #p:[1-3] priority tag from content
 // Returns the content without priority tag and the priority value (1=high, 2=medium, 3=low)
 // Returns nil priority if no valid tag is found
@@ -171,6 +207,57 @@ func ExtractDueDate(content string) (string, string) {
 	return cleanContent, dueDate
 }
 
+// ExtractDoneDate extracts the #done:YYYY-MM-DD completion date tag from content
+// Returns the content without the tag and the completion date (empty if none)
+func ExtractDoneDate(content string) (string, string) {
+	donePattern := regexp.MustCompile(`\s*#done:([0-9-]+)(?:\s|$)`)
+	matches := donePattern.FindStringSubmatch(content)
+
+	if matches == nil {
+		return content, ""
+	}
+
+	doneDate := matches[1]
+	cleanContent := donePattern.ReplaceAllString(content, " ")
+	cleanContent = strings.TrimSpace(cleanContent)
+
+	return cleanContent, doneDate
+}
+
+// ExtractCapturedDate extracts the #captured:YYYY-MM-DD tag from anywhere in content
+// Unlike ExtractTimestamp, the tag does not have to be at the end (tags may follow it)
+func ExtractCapturedDate(content string) (string, string) {
+	capturedPattern := regexp.MustCompile(`\s*#captured:([0-9-]+)(?:\s|$)`)
+	matches := capturedPattern.FindStringSubmatch(content)
+
+	if matches == nil {
+		return content, ""
+	}
+
+	capturedDate := matches[1]
+	cleanContent := capturedPattern.ReplaceAllString(content, " ")
+	cleanContent = strings.TrimSpace(cleanContent)
+
+	return cleanContent, capturedDate
+}
+
+// ExtractRef extracts the #ref:path[:line] code reference tag from content
+// Returns the content without the ref tag and the reference (empty if none)
+func ExtractRef(content string) (string, string) {
+	refPattern := regexp.MustCompile(`\s*#ref:([^\s]+)(?:\s|$)`)
+	matches := refPattern.FindStringSubmatch(content)
+
+	if matches == nil {
+		return content, ""
+	}
+
+	ref := matches[1]
+	cleanContent := refPattern.ReplaceAllString(content, " ")
+	cleanContent = strings.TrimSpace(cleanContent)
+
+	return cleanContent, ref
+}
+
 // ExtractTags extracts all freeform #tag markers from content
 // Returns the content without tags and a slice of tag names
 // Freeform tags are hashtags WITHOUT colons (e.g., #bug, #feature)
@@ -204,6 +204,81 @@ func TestExtractPriority(t *testing.T) {
 	}
 }
 
+func TestExtractRef(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expectedContent string
+		expectedRef     string
+	}{
+		{"ref with line", "Fix timeout #ref:api/server.go:42", "Fix timeout", "api/server.go:42"},
+		{"ref without line", "Review docs #ref:README.md #docs", "Review docs #docs", "README.md"},
+		{"no ref", "Regular task #bug", "Regular task #bug", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, ref := ExtractRef(tt.input)
+			if content != tt.expectedContent {
+				t.Errorf("Expected content '%s', got '%s'", tt.expectedContent, content)
+			}
+			if ref != tt.expectedRef {
+				t.Errorf("Expected ref '%s', got '%s'", tt.expectedRef, ref)
+			}
+		})
+	}
+}
+
+func TestExtractDoneDate(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expectedContent string
+		expectedDate    string
+	}{
+		{"done date at end", "Ship release #done:2026-01-15", "Ship release", "2026-01-15"},
+		{"done date between tags", "Fix bug #done:2026-01-15 #bug", "Fix bug #bug", "2026-01-15"},
+		{"no done date", "Regular task #bug", "Regular task #bug", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, date := ExtractDoneDate(tt.input)
+			if content != tt.expectedContent {
+				t.Errorf("Expected content '%s', got '%s'", tt.expectedContent, content)
+			}
+			if date != tt.expectedDate {
+				t.Errorf("Expected done date '%s', got '%s'", tt.expectedDate, date)
+			}
+		})
+	}
+}
+
+func TestExtractCapturedDate(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expectedContent string
+		expectedDate    string
+	}{
+		{"captured at end", "Call bank #captured:2026-01-10", "Call bank", "2026-01-10"},
+		{"captured before tags", "Fix bug #captured:2026-01-10 #bug", "Fix bug #bug", "2026-01-10"},
+		{"no captured date", "Regular task", "Regular task", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, date := ExtractCapturedDate(tt.input)
+			if content != tt.expectedContent {
+				t.Errorf("Expected content '%s', got '%s'", tt.expectedContent, content)
+			}
+			if date != tt.expectedDate {
+				t.Errorf("Expected captured date '%s', got '%s'", tt.expectedDate, date)
+			}
+		})
+	}
+}
+
 func TestExtractDueDate(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -391,3 +466,75 @@ func formatIntPtr(i *int) string {
 	}
 	return fmt.Sprintf("%d", *i)
 }
+
+func TestParseDumpFile_LineItemTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+	dumpFile := filepath.Join(tmpDir, "00_dump.md")
+
+	content := `# Dump
+
+[Link] https://go.dev/blog The Go Blog #captured:2024-01-21
+[Idea] Offline mode #captured:2024-01-22
+[Question] Who owns billing? #captured:2024-01-23
+[Unknown] Not a registered type
+- [ ] Regular task
+`
+
+	if err := os.WriteFile(dumpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	items, err := ParseDumpFile(dumpFile)
+	if err != nil {
+		t.Fatalf("ParseDumpFile failed: %v", err)
+	}
+
+	expected := []struct {
+		itemType ItemType
+		content  string
+		line     int
+	}{
+		{ItemTypeLink, "https://go.dev/blog The Go Blog #captured:2024-01-21", 3},
+		{ItemTypeIdea, "Offline mode #captured:2024-01-22", 4},
+		{ItemTypeQuestion, "Who owns billing? #captured:2024-01-23", 5},
+		{ItemTypeTodo, "Regular task", 7},
+	}
+
+	if len(items) != len(expected) {
+		t.Fatalf("Expected %d items, got %d", len(expected), len(items))
+	}
+
+	for i, exp := range expected {
+		if items[i].Type != exp.itemType {
+			t.Errorf("Item %d: expected type %s, got %s", i, exp.itemType, items[i].Type)
+		}
+		if items[i].Content != exp.content {
+			t.Errorf("Item %d: expected content '%s', got '%s'", i, exp.content, items[i].Content)
+		}
+		if items[i].StartLine != exp.line || items[i].EndLine != exp.line {
+			t.Errorf("Item %d: expected line %d, got %d-%d", i, exp.line, items[i].StartLine, items[i].EndLine)
+		}
+	}
+}
+
+func TestParseLink(t *testing.T) {
+	tests := []struct {
+		content       string
+		expectedURL   string
+		expectedTitle string
+	}{
+		{"https://go.dev/blog The Go Blog", "https://go.dev/blog", "The Go Blog"},
+		{"https://example.com", "https://example.com", "https://example.com"},
+		{"  https://example.com  ", "https://example.com", "https://example.com"},
+	}
+
+	for _, tt := range tests {
+		url, title := ParseLink(tt.content)
+		if url != tt.expectedURL {
+			t.Errorf("ParseLink(%q): expected URL '%s', got '%s'", tt.content, tt.expectedURL, url)
+		}
+		if title != tt.expectedTitle {
+			t.Errorf("ParseLink(%q): expected title '%s', got '%s'", tt.content, tt.expectedTitle, title)
+		}
+	}
+}
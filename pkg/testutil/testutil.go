@@ -31,11 +31,13 @@ func SetupTestBrain(t *testing.T) *TestBrain {
 	configPath := filepath.Join(configDir, "config.json")
 	brainPath := filepath.Join(tmpDir, "test-brain")
 	symlinkPath := filepath.Join(tmpDir, "brain-link")
+	cacheDir := filepath.Join(tmpDir, "cache")
 
 	// Set environment variables for this test
 	t.Setenv("BRAIN_CONFIG_DIR", configDir)
 	t.Setenv("BRAIN_CONFIG_PATH", configPath)
 	t.Setenv("BRAIN_SYMLINK", symlinkPath)
+	t.Setenv("BRAIN_CACHE_DIR", cacheDir)
 
 	// Create brain directory structure
 	activeDirPath := filepath.Join(brainPath, "01_active")